@@ -1,15 +1,370 @@
 package compiler
 
-//Alias is a constant level alias.
-type Alias Cache
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/qlova/viking/compiler/scanner"
+)
+
+//Alias is a constant level alias, name = value
+//Aliases with parameters are macros, name(a, b) = a + b, the value of a macro can also be a block of statements.
+type Alias struct {
+	Cache
+
+	Name       Token
+	Parameters []Token
+
+	//Locals are the variables that the macro introduces, they are renamed in each expansion so that they cannot capture the caller's names.
+	Locals []Token
+
+	Macro, Block bool
+}
+
+//maximumExpansions is the deepest that macros can expand inside of each other, so that a macro that uses itself is reported.
+const maximumExpansions = 100
+
+//recursiveMacro is the error of a macro that expands inside of itself too many times, it is reported once at the outermost call site.
+type recursiveMacro struct {
+	error
+}
 
 //UnpackAlias uses an alias.
 func (compiler *Compiler) UnpackAlias(alias Alias) {
-	var cache = Cache(alias)
+	var cache = alias.Cache
 	compiler.PushReader(&cache.Buffer)
 }
 
 //DefineAlias defines a new alias.
 func (compiler *Compiler) DefineAlias(name Token) {
-	compiler.Aliases[name.String()] = Alias(compiler.CacheLine())
+	compiler.Aliases[name.String()] = Alias{Cache: compiler.CacheLine(), Name: name}
+}
+
+//DefineMacro defines a new macro with the given parameters, the value is the rest of the line or the block that follows.
+func (compiler *Compiler) DefineMacro(name Token, parameters []Argument) error {
+	var macro = Alias{Name: name, Macro: true}
+
+	for _, parameter := range parameters {
+		if Defined(parameter.Type) || parameter.Variadic {
+			return compiler.NewError("macro parameters do not have types, ", name.String(), "(a, b) = a + b")
+		}
+		macro.Parameters = append(macro.Parameters, parameter.Token)
+	}
+
+	if first := compiler.Peek(); first.Is("\n") {
+		macro.Block = true
+		macro.Cache = compiler.CacheBlock()
+
+		//The newline was scanned by the peek, before the block was cached.
+		macro.LineNumber--
+
+		//The block's closing } is not part of the expansion.
+		macro.Truncate(bytes.LastIndexByte(macro.Bytes(), '}'))
+	} else {
+		macro.Cache = compiler.CacheLine()
+
+		//The line is cached from after the token that was peeked.
+		var line = append(append(append([]byte(nil), first...), ' '), macro.Bytes()...)
+		macro.Reset()
+		macro.Write(line)
+	}
+
+	macro.Locals = locals(macro.Bytes(), macro.Parameters)
+
+	compiler.Aliases[name.String()] = macro
+	return nil
+}
+
+//ExpandExpression expands a call to a macro inside of an expression.
+func (compiler *Compiler) ExpandExpression(macro Alias) (Expression, error) {
+	if macro.Block {
+		return Expression{}, compiler.NewError(macro.Name.String(), " expands to a block, it cannot be used as a value")
+	}
+
+	code, err := compiler.expand(macro)
+	if err != nil {
+		return Expression{}, err
+	}
+
+	compiler.expanding++
+	defer func() { compiler.expanding-- }()
+
+	var saved = compiler.Scanner
+	compiler.Scanner = macro.scanner(append(append([]byte("("), code...), ')'))
+
+	expression, err := compiler.ScanExpression()
+	compiler.Scanner = saved
+
+	if err != nil {
+		return expression, macro.Error(compiler, err)
+	}
+	return expression, nil
+}
+
+//ExpandStatements expands a call to a macro as statements.
+func (compiler *Compiler) ExpandStatements(macro Alias) error {
+	code, err := compiler.expand(macro)
+	if err != nil {
+		return err
+	}
+
+	compiler.expanding++
+	defer func() { compiler.expanding-- }()
+
+	var saved = compiler.Scanner
+	compiler.Scanner = macro.scanner(code)
+
+	for {
+		err := compiler.CompileStatement()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			compiler.Scanner = saved
+			return macro.Error(compiler, err)
+		}
+
+		compiler.Go.Write([]byte("\n"))
+		compiler.JS.Write([]byte("\n"))
+	}
+
+	compiler.Scanner = saved
+	return nil
+}
+
+//Error returns an error inside of an expansion of the macro, at the call site.
+//The error that it wraps points to the line of the macro's definition.
+func (macro Alias) Error(compiler *Compiler, err error) error {
+	if _, ok := err.(recursiveMacro); ok {
+		if compiler.expanding > 1 {
+			return err
+		}
+		return compiler.NewError(err.Error())
+	}
+	return compiler.NewError("inside of ", macro.Name.String(), ", ", err.Error())
+}
+
+//scanner returns a scanner of the code of an expansion, with the line numbers of the macro's definition.
+func (macro Alias) scanner(code []byte) scanner.Scanner {
+	var s = scanner.Scanner{
+		Filename:   macro.Filename,
+		LineNumber: macro.LineNumber,
+	}
+	s.SetReader(bytes.NewReader(code))
+	return s
+}
+
+//expand scans the arguments of a call to the macro and returns the code of its expansion.
+func (compiler *Compiler) expand(macro Alias) ([]byte, error) {
+	if !compiler.ScanIf('(') {
+		return nil, compiler.NewError(macro.Name.String(), " is a macro, expecting (")
+	}
+
+	if compiler.expanding >= maximumExpansions {
+		return nil, recursiveMacro{errors.New(macro.Name.String() + " expands inside of itself too many times")}
+	}
+
+	arguments, err := compiler.macroArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(arguments) != len(macro.Parameters) {
+		return nil, compiler.NewError(macro.Name.String(), " takes ", len(macro.Parameters), " arguments, not ", len(arguments))
+	}
+
+	var replacements = make(map[string][]byte)
+	for i, parameter := range macro.Parameters {
+		replacements[parameter.String()] = arguments[i]
+	}
+
+	compiler.expansions++
+	for _, local := range macro.Locals {
+		replacements[local.String()] = []byte(fmt.Sprint(local, "ʹ", letters(compiler.expansions)))
+	}
+
+	return rewrite(macro.Bytes(), replacements), nil
+}
+
+//macroArguments scans the code of each argument of a macro call, arguments that are more than one token are bracketed.
+func (compiler *Compiler) macroArguments() (arguments [][]byte, err error) {
+	var argument [][]byte
+	var depth int
+
+	var add = func() {
+		if len(argument) == 1 {
+			arguments = append(arguments, argument[0])
+		} else {
+			arguments = append(arguments, append(append([]byte("("), bytes.Join(argument, []byte(" "))...), ')'))
+		}
+		argument = nil
+	}
+
+	for {
+		var token = compiler.Scan()
+
+		switch {
+		case token == nil || token.Is("\n"):
+			return nil, compiler.Expecting(')')
+
+		case (token.Is(")") || token.Is(",")) && depth == 0:
+			if len(argument) == 0 {
+				if token.Is(")") && len(arguments) == 0 {
+					return nil, nil
+				}
+				return nil, compiler.NewError("expecting an argument")
+			}
+			add()
+			if token.Is(")") {
+				return arguments, nil
+			}
+			continue
+
+		case token.Is("(") || token.Is("["):
+			depth++
+
+		case token.Is(")") || token.Is("]"):
+			depth--
+		}
+
+		argument = append(argument, token)
+	}
+}
+
+//locals returns the variables that are introduced by the code, name $= value and for name in
+func locals(code []byte, parameters []Token) (names []Token) {
+	var defined = make(map[string]bool)
+	for _, parameter := range parameters {
+		defined[parameter.String()] = true
+	}
+
+	var tokens []Token
+
+	var s scanner.Scanner
+	s.SetReader(bytes.NewReader(code))
+	for token := s.Scan(); token != nil; token = s.Scan() {
+		tokens = append(tokens, token)
+	}
+
+	for i, token := range tokens {
+		if defined[token.String()] || i+1 >= len(tokens) || (i > 0 && tokens[i-1].Is(".")) {
+			continue
+		}
+
+		var introduced = i+2 < len(tokens) && tokens[i+1].Is("$") && tokens[i+2].Is("=")
+		if i > 0 && tokens[i-1].Is("for") && tokens[i+1].Is("in") {
+			introduced = true
+		}
+
+		if introduced {
+			defined[token.String()] = true
+			names = append(names, token)
+		}
+	}
+
+	return names
+}
+
+//rewrite returns the code with the names that have replacements replaced, including those inside of string interpolations.
+func rewrite(code []byte, replacements map[string][]byte) []byte {
+	var result []byte
+	var previous Token
+
+	var s scanner.Scanner
+	s.SetReader(bytes.NewReader(code))
+	for token := s.Scan(); token != nil; token = s.Scan() {
+		switch {
+		case token[0] == '"':
+			result = append(result, rewriteString(token, replacements)...)
+
+		case len(token) > 2 && token[0] == '/' && token[1] == '/':
+			result = append(result, token...)
+			result = append(result, '\n')
+
+		default:
+			//Fields are not renamed.
+			if replacement, ok := replacements[token.String()]; ok && !previous.Is(".") {
+				result = append(result, replacement...)
+			} else {
+				result = append(result, token...)
+			}
+		}
+
+		result = append(result, ' ')
+		previous = token
+	}
+
+	return result
+}
+
+//rewriteString rewrites the interpolations inside of a string literal.
+func rewriteString(literal Token, replacements map[string][]byte) []byte {
+	var result []byte
+
+	for i := 0; i < len(literal); i++ {
+		switch literal[i] {
+		case '\\':
+			result = append(result, literal[i])
+			i++
+
+		case '{':
+			var end = Interpolation(literal, i)
+			if end < 0 {
+				return literal
+			}
+			result = append(result, '{')
+			result = append(result, bytes.TrimSpace(rewrite(literal[i+1:end], replacements))...)
+			result = append(result, '}')
+			i = end
+			continue
+		}
+
+		if i < len(literal) {
+			result = append(result, literal[i])
+		}
+	}
+
+	return result
+}
+
+//Interpolation returns the index of the } that closes the string interpolation starting at i, or -1 when it isn't closed.
+func Interpolation(literal []byte, i int) int {
+	var depth int
+	for ; i < len(literal); i++ {
+		switch literal[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"':
+			//Skip nested strings.
+			for i++; i < len(literal) && literal[i] != '"'; i++ {
+				switch literal[i] {
+				case '\\':
+					i++
+				case '{':
+					if i = Interpolation(literal, i); i < 0 {
+						return -1
+					}
+				}
+			}
+		}
+	}
+	return -1
+}
+
+//letters returns n written with letters, so that it can be part of a name, 1 is a and 27 is aa
+func letters(n int) string {
+	var name []byte
+	for n > 0 {
+		n--
+		name = append([]byte{byte('a' + n%26)}, name...)
+		n /= 26
+	}
+	return string(name)
 }
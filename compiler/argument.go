@@ -54,7 +54,7 @@ func (compiler *Compiler) Indicies() (indicies []Expression, err error) {
 		return nil, nil
 	}
 	if !compiler.ScanIf(']') {
-		first, err := compiler.ScanExpression()
+		first, err := compiler.scanIndex()
 		if err != nil {
 			return nil, err
 		}
@@ -62,7 +62,7 @@ func (compiler *Compiler) Indicies() (indicies []Expression, err error) {
 		indicies = append(indicies, first)
 
 		for compiler.ScanIf(',') {
-			expression, err := compiler.ScanExpression()
+			expression, err := compiler.scanIndex()
 			if err != nil {
 				return nil, err
 			}
@@ -76,6 +76,35 @@ func (compiler *Compiler) Indicies() (indicies []Expression, err error) {
 	return
 }
 
+//scanIndex scans an index expression or a range of indices, a to b, to b or a to.
+func (compiler *Compiler) scanIndex() (Expression, error) {
+	var index Range
+
+	if !compiler.Peek().Is("to") {
+		from, err := compiler.ScanExpression()
+		if err != nil {
+			return from, err
+		}
+		if !compiler.Peek().Is("to") {
+			return from, nil
+		}
+		index.From = from
+	}
+	compiler.Scan()
+
+	if !compiler.Peek().Is("]") && !compiler.Peek().Is(",") {
+		to, err := compiler.ScanExpression()
+		if err != nil {
+			return to, err
+		}
+		index.To = to
+	}
+
+	var expression = compiler.NewExpression()
+	expression.Type = index
+	return expression, nil
+}
+
 //ScanArguments scans a function/concept argument definition.
 func (compiler *Compiler) ScanArguments() ([]Argument, error) {
 	var arguments []Argument
@@ -83,6 +112,18 @@ func (compiler *Compiler) ScanArguments() ([]Argument, error) {
 	for {
 		var token = compiler.Scan()
 
+		//Specified argument filters, such as list.integer(l) or function[integer].integer(f)
+		var specified Type
+		if T := compiler.Type(token); Defined(T) && (compiler.Peek().Is("[") || compiler.Peek().Is(".")) {
+			var err error
+			if specified, err = compiler.SpecifyType(T); err != nil {
+				return nil, err
+			}
+			if !compiler.Peek().Is("(") {
+				return nil, compiler.Expecting('(')
+			}
+		}
+
 		if compiler.ScanIf('(') {
 			var filter = token
 
@@ -95,6 +136,9 @@ func (compiler *Compiler) ScanArguments() ([]Argument, error) {
 			if err != nil {
 				return nil, err
 			}
+			if specified != nil {
+				T = specified
+			}
 
 			for {
 				var token = compiler.Scan()
@@ -111,6 +155,10 @@ func (compiler *Compiler) ScanArguments() ([]Argument, error) {
 					variadic = true
 				}
 
+				if err := compiler.Unshadowed(token, "an argument"); err != nil {
+					return nil, err
+				}
+
 				arguments = append(arguments, Argument{
 					Token:    token,
 					Type:     T,
@@ -126,6 +174,9 @@ func (compiler *Compiler) ScanArguments() ([]Argument, error) {
 				}
 			}
 		} else {
+			if err := compiler.Unshadowed(token, "an argument"); err != nil {
+				return nil, err
+			}
 			arguments = append(arguments, Argument{
 				Token: token,
 			})
@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Classify checks the Unicode class of a symbol, isletter('a') is true.
+type Classify struct {
+	name, function string
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Classify{name: "isletter", function: "IsLetter"})
+var _ = compiler.RegisterBuiltin(Classify{name: "isdigit", function: "IsDigit"})
+var _ = compiler.RegisterBuiltin(Classify{name: "isspace", function: "IsSpace"})
+var _ = compiler.RegisterBuiltin(Classify{name: "isupper", function: "IsUpper"})
+var _ = compiler.RegisterBuiltin(Classify{name: "islower", function: "IsLower"})
+var _ = compiler.RegisterBuiltin(Classify{name: "ispunct", function: "IsPunct"})
+
+//Name returns the name of the class.
+func (classify Classify) Name() compiler.String {
+	return compiler.String{
+		compiler.English: classify.name,
+	}
+}
+
+//Run does nothing.
+func (classify Classify) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError(classify.name, " cannot be called as statement")
+}
+
+//Call calls the classification.
+func (classify Classify) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 1 || !args[0].Equals(types.Symbol{}) {
+		return expression, c.NewError(classify.name, " takes a symbol")
+	}
+
+	c.Import("unicode")
+
+	expression.Type = types.Logical{}
+	fmt.Fprintf(&expression.Go, `unicode.%v(%v)`, classify.function, args[0].Go)
+
+	return expression, nil
+}
@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Clear removes all of the values from a list.
+type Clear struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Clear{})
+
+//Name returns clear's name.
+func (Clear) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `clear`,
+	}
+}
+
+//Call does nothing.
+func (Clear) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("clear cannot be called as an expression")
+}
+
+//Run runs clear.
+func (Clear) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 1 {
+		return c.NewError("clear takes a list")
+	}
+
+	if err := modifiable(c, "clear", args[0]); err != nil {
+		return err
+	}
+
+	if list, ok := args[0].Type.(types.List); ok {
+		list.Clear(c, args[0])
+		return nil
+	}
+
+	return c.NewError("cannot clear " + args[0].String(c))
+}
@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Close closes a channel, no more values can be sent on it.
+type Close struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Close{})
+
+//Name returns close's name.
+func (Close) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `close`,
+	}
+}
+
+//Call does nothing.
+func (Close) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("close cannot be called as an expression")
+}
+
+//Run runs close.
+func (Close) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 1 {
+		return c.NewError("close takes a channel")
+	}
+
+	if err := modifiable(c, "close", args[0]); err != nil {
+		return err
+	}
+
+	if channel, ok := args[0].Type.(types.Channel); ok {
+		channel.Close(c, args[0])
+		return nil
+	}
+
+	return c.NewError("cannot close " + args[0].String(c))
+}
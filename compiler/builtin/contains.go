@@ -0,0 +1,58 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Contains checks if a collection contains a value, or a value that a function returns true for.
+//Strings contain strings and symbols.
+type Contains struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Contains{})
+
+//Name returns contains's name.
+func (Contains) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `contains`,
+	}
+}
+
+//Run does nothing.
+func (Contains) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("contains cannot be called as statement")
+}
+
+//Call calls contains.
+func (Contains) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 {
+		return expression, c.NewError("contains takes a collection and a value")
+	}
+
+	var collection, value = args[0], args[1]
+
+	if table, ok := collection.Type.(types.Table); ok {
+		return table.Contains(c, collection, value)
+	}
+
+	var search = search
+	if collection.Equals(types.String{}) {
+		search = substring
+	}
+
+	position, err := search(c, collection, value)
+	if err != nil {
+		return expression, err
+	}
+
+	expression.Type = types.Logical{}
+	fmt.Fprintf(&expression.Go, `(%v >= 0)`, position)
+
+	return expression, nil
+}
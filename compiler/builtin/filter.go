@@ -0,0 +1,61 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Filter returns a list of the values of a collection that a function returns true for.
+type Filter struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Filter{})
+
+//Name returns filter's name.
+func (Filter) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `filter`,
+	}
+}
+
+//Run does nothing.
+func (Filter) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("filter cannot be called as statement")
+}
+
+//Call calls filter.
+func (Filter) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 {
+		return expression, c.NewError("filter takes a collection and a function")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+
+	function, err := functionArgument(c, args[1])
+	if err != nil {
+		return expression, err
+	}
+
+	keep, err := function.Apply(c, args[1], variable(c, "value_", subtype(elements)))
+	if err != nil {
+		return expression, err
+	}
+
+	if !keep.Equals(types.Logical{}) {
+		return expression, c.NewError("filter takes a function that returns a logical, not ", keep.String(c))
+	}
+
+	expression.Type = elements.Type
+	fmt.Fprintf(&expression.Go, `func(list_ %v) %v { var result_ = make(%v, 0, len(list_)); for _, value_ := range list_ { if %v { result_ = append(result_, value_) } }; return result_ }(%v)`,
+		elements.Type.Native(c), elements.Type.Native(c), elements.Type.Native(c), keep.Go, elements.Go)
+
+	return expression, nil
+}
@@ -0,0 +1,105 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Find returns the index of the first value of a collection that is equal to a value, or that a function returns true for.
+//Find returns -1 when nothing is found, inside of strings it returns the symbol position of a string or symbol.
+type Find struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Find{})
+
+//Name returns find's name.
+func (Find) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `find`,
+	}
+}
+
+//Run does nothing.
+func (Find) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("find cannot be called as statement")
+}
+
+//Call calls find.
+func (Find) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 {
+		return expression, c.NewError("find takes a collection and a value or function")
+	}
+
+	var search = search
+	if args[0].Equals(types.String{}) {
+		search = substring
+	}
+
+	position, err := search(c, args[0], args[1])
+	if err != nil {
+		return expression, err
+	}
+
+	expression.Type = types.Integer{}
+	fmt.Fprintf(&expression.Go, `I.NewInteger(int64(%v))`, position)
+
+	return expression, nil
+}
+
+//search returns Go code that evaluates to the native position of the first matching value inside of the collection, or -1.
+func search(c *compiler.Compiler, collection, match compiler.Expression) (string, error) {
+	elements, err := types.Elements(c, collection)
+	if err != nil {
+		return "", err
+	}
+
+	var value = variable(c, "value_", subtype(elements))
+
+	if function, ok := match.Type.(types.Function); ok {
+		matches, err := function.Apply(c, match, value)
+		if err != nil {
+			return "", err
+		}
+		if !matches.Equals(types.Logical{}) {
+			return "", c.NewError("expecting a function that returns a logical, not ", matches.String(c))
+		}
+
+		return fmt.Sprintf(`func(list_ %v) int { for i_, value_ := range list_ { if %v { return i_ } }; return -1 }(%v)`,
+			elements.Type.Native(c), matches.Go, elements.Go), nil
+	}
+
+	ok, equals, err := value.Type.Operation(c, value, variable(c, "target_", match.Type), "=")
+	if err != nil {
+		return "", err
+	}
+	if !ok || !equals.Equals(types.Logical{}) {
+		return "", c.NewError("cannot compare ", value.String(c), " with ", match.String(c))
+	}
+
+	return fmt.Sprintf(`func(list_ %v, target_ %v) int { for i_, value_ := range list_ { if %v { return i_ } }; return -1 }(%v, %v)`,
+		elements.Type.Native(c), match.Type.Native(c), equals.Go, elements.Go, match.Go), nil
+}
+
+//substring returns Go code that evaluates to the symbol position of a string or symbol inside of a string, or -1.
+func substring(c *compiler.Compiler, text, match compiler.Expression) (string, error) {
+	var needle = match.Go.String()
+
+	switch match.Type.(type) {
+	case types.String:
+	case types.Symbol:
+		needle = `string(` + needle + `)`
+	default:
+		return "", c.NewError("cannot find ", match.String(c), " inside of a string")
+	}
+
+	c.Import("strings")
+	c.Import("unicode/utf8")
+
+	return fmt.Sprintf(`func(text, match string) int { var i = strings.Index(text, match); if i < 0 { return -1 }; return utf8.RuneCountInString(text[:i]) }(%v, %v)`,
+		text.Go, needle), nil
+}
@@ -0,0 +1,57 @@
+package builtin
+
+import (
+	"strings"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Insert inserts a value into a list at an index.
+type Insert struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Insert{})
+
+//Name returns insert's name.
+func (Insert) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `insert`,
+	}
+}
+
+//Call does nothing.
+func (Insert) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("insert cannot be called as an expression")
+}
+
+//Run runs insert.
+func (Insert) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 3 {
+		return c.NewError("insert takes a list, an index and a value")
+	}
+
+	if err := modifiable(c, "insert into", args[0]); err != nil {
+		return err
+	}
+
+	if list, ok := args[0].Type.(types.List); ok {
+		return list.Insert(c, args[0], args[1], args[2])
+	}
+
+	return c.NewError("cannot insert into " + args[0].String(c))
+}
+
+//modifiable returns an error when the value belongs to a variable from outside of the parallel loop being compiled, builtins cannot change it in place.
+func modifiable(c *compiler.Compiler, builtin string, value compiler.Expression) error {
+	var name = value.Go.String()
+	if end := strings.IndexAny(name, ".["); end >= 0 {
+		name = name[:end]
+	}
+
+	if c.Shared(compiler.Token(name)) {
+		return c.NewError("cannot ", builtin, " ", name, " inside of a parallel loop")
+	}
+	return nil
+}
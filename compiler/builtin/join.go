@@ -0,0 +1,56 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Join joins a collection of strings together into one string, with an optional separator between them.
+type Join struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Join{})
+
+//Name returns join's name.
+func (Join) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `join`,
+	}
+}
+
+//Run does nothing.
+func (Join) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("join cannot be called as statement")
+}
+
+//Call calls join.
+func (Join) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) < 1 || len(args) > 2 || !allStrings(args[1:]...) {
+		return expression, c.NewError("join takes a collection of strings and an optional string separator")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+	if !subtype(elements).Equals(types.String{}) {
+		return expression, c.NewError("join takes a collection of strings, not ", args[0].String(c))
+	}
+
+	var separator = `""`
+	if len(args) == 2 {
+		separator = args[1].Go.String()
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	fmt.Fprintf(&expression.Go, `strings.Join(%v, %v)`, elements.Go, separator)
+
+	return expression, nil
+}
@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Lower returns a string with all of its symbols in lower case, or a symbol in lower case.
+type Lower struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Lower{})
+
+//Name returns lower's name.
+func (Lower) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `lower`,
+	}
+}
+
+//Run does nothing.
+func (Lower) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("lower cannot be called as statement")
+}
+
+//Call calls lower.
+func (Lower) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) == 1 && args[0].Equals(types.Symbol{}) {
+		c.Import("unicode")
+
+		expression.Type = types.Symbol{}
+		fmt.Fprintf(&expression.Go, `unicode.ToLower(%v)`, args[0].Go)
+		return expression, nil
+	}
+
+	if len(args) != 1 || !allStrings(args...) {
+		return expression, c.NewError("lower takes a string or a symbol")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	fmt.Fprintf(&expression.Go, `strings.ToLower(%v)`, args[0].Go)
+
+	return expression, nil
+}
@@ -0,0 +1,83 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Map applies a function to each value of a collection and returns a list of the results.
+type Map struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Map{})
+
+//Name returns map's name.
+func (Map) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `map`,
+	}
+}
+
+//Run does nothing.
+func (Map) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("map cannot be called as statement")
+}
+
+//Call calls map.
+func (Map) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 {
+		return expression, c.NewError("map takes a collection and a function")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+
+	function, err := functionArgument(c, args[1])
+	if err != nil {
+		return expression, err
+	}
+
+	mapped, err := function.Apply(c, args[1], variable(c, "value_", subtype(elements)))
+	if err != nil {
+		return expression, err
+	}
+
+	var list = types.List{}.With(c, mapped.Type)
+
+	expression.Type = list
+	fmt.Fprintf(&expression.Go, `func(list_ %v) %v { var result_ = make(%v, len(list_)); for i_, value_ := range list_ { result_[i_] = %v }; return result_ }(%v)`,
+		elements.Type.Native(c), list.Native(c), list.Native(c), mapped.Go, elements.Go)
+
+	return expression, nil
+}
+
+//functionArgument returns the function that was passed to a higher-order builtin.
+func functionArgument(c *compiler.Compiler, argument compiler.Expression) (types.Function, error) {
+	function, ok := argument.Type.(types.Function)
+	if !ok {
+		return function, c.NewError("expecting a function, not ", argument.String(c))
+	}
+	return function, nil
+}
+
+//variable returns an expression that refers to the native variable with the given name and type.
+//The names end with _ so that they cannot capture the names of the program, which cannot contain _
+func variable(c *compiler.Compiler, name string, T compiler.Type) compiler.Expression {
+	var expression = c.NewExpression()
+	expression.Type = T
+	expression.Go.WriteString(name)
+	expression.JS.WriteString(name)
+	return expression
+}
+
+//subtype returns the subtype of the elements of a collection.
+func subtype(elements compiler.Expression) compiler.Type {
+	return elements.Type.(compiler.Collection).Subtype()
+}
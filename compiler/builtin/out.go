@@ -36,6 +36,13 @@ func (Out) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.
 	c.Go.Write([]byte("fmt.Print("))
 
 	for i, argument := range args {
+		if _, ok := argument.Type.(compiler.Thing); ok {
+			argument, err = types.String{}.Cast(c, argument, types.String{})
+			if err != nil {
+				return err
+			}
+		}
+
 		if argument.Type.Equals(types.Symbol{}) {
 			c.Go.Write([]byte("string("))
 			c.Go.Write(argument.Go.Bytes())
@@ -0,0 +1,59 @@
+package builtin
+
+import (
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Pop removes and returns the last value of a list, or the value at an index, or receives a value from a channel.
+type Pop struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Pop{})
+
+//Name returns pop's name.
+func (Pop) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `pop`,
+	}
+}
+
+//Call calls pop.
+func (Pop) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	if len(args) != 1 && len(args) != 2 {
+		return expression, c.NewError("pop takes a list and an optional index")
+	}
+
+	var index compiler.Expression
+	if len(args) == 2 {
+		index = args[1]
+	}
+
+	if list, ok := args[0].Type.(types.List); ok {
+		if err := modifiable(c, "pop from", args[0]); err != nil {
+			return expression, err
+		}
+		return list.Pop(c, args[0], index)
+	}
+
+	if channel, ok := args[0].Type.(types.Channel); ok && len(args) == 1 {
+		return channel.Pop(c, args[0]), nil
+	}
+
+	return expression, c.NewError("cannot pop from " + args[0].String(c))
+}
+
+//Run runs pop and discards the value.
+func (Pop) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	expression, err := Pop{}.Call(c, this, args...)
+	if err != nil {
+		return err
+	}
+
+	c.Indent()
+	c.Go.WriteB(expression.Go)
+	c.JS.WriteString("void ")
+	c.JS.WriteB(expression.JS)
+	return nil
+}
@@ -38,6 +38,13 @@ func (Print) Run(c *compiler.Compiler, this compiler.Expression, args ...compile
 	c.Go.Write([]byte("fmt.Println("))
 
 	for i, argument := range args {
+		if _, ok := argument.Type.(compiler.Thing); ok {
+			argument, err = types.String{}.Cast(c, argument, types.String{})
+			if err != nil {
+				return err
+			}
+		}
+
 		if argument.Type.Equals(types.Symbol{}) {
 			c.Go.Write([]byte("string("))
 			c.Go.Write(argument.Go.Bytes())
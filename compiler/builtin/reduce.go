@@ -0,0 +1,74 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Reduce combines the values of a collection with a function, starting from an optional initial value.
+//Without an initial value, the first value of the collection is used and an empty collection reduces to the zero value.
+type Reduce struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Reduce{})
+
+//Name returns reduce's name.
+func (Reduce) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `reduce`,
+	}
+}
+
+//Run does nothing.
+func (Reduce) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("reduce cannot be called as statement")
+}
+
+//Call calls reduce.
+func (Reduce) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 && len(args) != 3 {
+		return expression, c.NewError("reduce takes a collection, a function and an optional initial value")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+
+	function, err := functionArgument(c, args[1])
+	if err != nil {
+		return expression, err
+	}
+
+	var result = subtype(elements)
+	if len(args) == 3 {
+		result = args[2].Type
+	}
+
+	reduced, err := function.Apply(c, args[1], variable(c, "result_", result), variable(c, "value_", subtype(elements)))
+	if err != nil {
+		return expression, err
+	}
+
+	if !reduced.Equals(result) {
+		return expression, c.NewError("reduce takes a function that returns ", result.String(c), ", not ", reduced.String(c))
+	}
+
+	expression.Type = result
+
+	if len(args) == 3 {
+		fmt.Fprintf(&expression.Go, `func(list_ %v, result_ %v) %v { for _, value_ := range list_ { result_ = %v }; return result_ }(%v, %v)`,
+			elements.Type.Native(c), result.Native(c), result.Native(c), reduced.Go, elements.Go, args[2].Go)
+		return expression, nil
+	}
+
+	fmt.Fprintf(&expression.Go, `func(list_ %v) %v { if len(list_) == 0 { return %v }; var result_ = list_[0]; for _, value_ := range list_[1:] { result_ = %v }; return result_ }(%v)`,
+		elements.Type.Native(c), result.Native(c), result.Zero(c).Go, reduced.Go, elements.Go)
+
+	return expression, nil
+}
@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Remove removes the value at an index from a list.
+type Remove struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Remove{})
+
+//Name returns remove's name.
+func (Remove) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `remove`,
+	}
+}
+
+//Call does nothing.
+func (Remove) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("remove cannot be called as an expression, do you mean pop?")
+}
+
+//Run runs remove.
+func (Remove) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 2 {
+		return c.NewError("remove takes a list and an index")
+	}
+
+	if err := modifiable(c, "remove from", args[0]); err != nil {
+		return err
+	}
+
+	if list, ok := args[0].Type.(types.List); ok {
+		expression, err := list.Pop(c, args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		c.Indent()
+		c.Go.WriteB(expression.Go)
+		c.JS.WriteString("void ")
+		c.JS.WriteB(expression.JS)
+		return nil
+	}
+
+	return c.NewError("cannot remove from " + args[0].String(c))
+}
@@ -0,0 +1,44 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Repeat returns a string repeated a number of times.
+type Repeat struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Repeat{})
+
+//Name returns repeat's name.
+func (Repeat) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `repeat`,
+	}
+}
+
+//Run does nothing.
+func (Repeat) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("repeat cannot be called as statement")
+}
+
+//Call calls repeat.
+func (Repeat) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 2 || !allStrings(args[0]) || !args[1].Equals(types.Integer{}) {
+		return expression, c.NewError("repeat takes a string and an integer")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	fmt.Fprintf(&expression.Go, `func(text string, count int64) string { if count < 0 { return "" }; return strings.Repeat(text, int(count)) }(%v, %v.Int64())`,
+		args[0].Go, args[1].Go)
+
+	return expression, nil
+}
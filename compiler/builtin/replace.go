@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Replace returns a copy of a string where each occurrence of a string is replaced with another string.
+type Replace struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Replace{})
+
+//Name returns replace's name.
+func (Replace) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `replace`,
+	}
+}
+
+//Run does nothing.
+func (Replace) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("replace cannot be called as statement")
+}
+
+//Call calls replace.
+func (Replace) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 3 || !allStrings(args...) {
+		return expression, c.NewError("replace takes a string, the string to replace and its replacement")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	fmt.Fprintf(&expression.Go, `strings.Replace(%v, %v, %v, -1)`, args[0].Go, args[1].Go, args[2].Go)
+
+	return expression, nil
+}
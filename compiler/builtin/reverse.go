@@ -0,0 +1,70 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Reverse reverses the order of a collection.
+//As an expression, reverse returns a reversed list, as a statement, the list or array is reversed in place.
+type Reverse struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Reverse{})
+
+//Name returns reverse's name.
+func (Reverse) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `reverse`,
+	}
+}
+
+//Call calls reverse.
+func (Reverse) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 1 {
+		return expression, c.NewError("reverse takes a collection")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+
+	expression.Type = elements.Type
+	fmt.Fprintf(&expression.Go, `func(list %v) %v { var reversed = make(%v, len(list)); for i, value := range list { reversed[len(list)-1-i] = value }; return reversed }(%v)`,
+		elements.Type.Native(c), elements.Type.Native(c), elements.Type.Native(c), elements.Go)
+
+	return expression, nil
+}
+
+//Run reverses a list or array in place.
+func (Reverse) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 1 {
+		return c.NewError("reverse takes a collection")
+	}
+
+	if err := modifiable(c, "reverse", args[0]); err != nil {
+		return err
+	}
+
+	var list, slice = args[0], args[0].Go.String()
+
+	switch list.Type.(type) {
+	case types.List:
+	case types.Array:
+		slice += `[:]`
+	default:
+		return c.NewError("cannot reverse " + list.String(c) + " in place")
+	}
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, `func(list %v) { for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 { list[i], list[j] = list[j], list[i] } }(%v)`,
+		types.List{}.With(c, list.Type.(compiler.Collection).Subtype()).Native(c), slice)
+
+	return nil
+}
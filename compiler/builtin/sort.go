@@ -0,0 +1,116 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Sort sorts a collection in ascending order, or with a function that returns true when its first argument comes before its second.
+//As an expression, sort returns a sorted list, as a statement, the list or array is sorted in place.
+type Sort struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Sort{})
+
+//Name returns sort's name.
+func (Sort) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `sort`,
+	}
+}
+
+//Call calls sort.
+func (Sort) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) != 1 && len(args) != 2 {
+		return expression, c.NewError("sort takes a collection and an optional function")
+	}
+
+	elements, err := types.Elements(c, args[0])
+	if err != nil {
+		return expression, err
+	}
+
+	less, err := before(c, subtype(elements), args[1:]...)
+	if err != nil {
+		return expression, err
+	}
+
+	c.Import("sort")
+
+	expression.Type = elements.Type
+	fmt.Fprintf(&expression.Go, `func(list_ %v) %v { var sorted_ = make(%v, len(list_)); copy(sorted_, list_); sort.SliceStable(sorted_, func(i_, j_ int) bool { var a_, b_ = sorted_[i_], sorted_[j_]; return %v }); return sorted_ }(%v)`,
+		elements.Type.Native(c), elements.Type.Native(c), elements.Type.Native(c), less.Go, elements.Go)
+
+	return expression, nil
+}
+
+//Run sorts a list or array in place.
+func (Sort) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 1 && len(args) != 2 {
+		return c.NewError("sort takes a collection and an optional function")
+	}
+
+	if err := modifiable(c, "sort", args[0]); err != nil {
+		return err
+	}
+
+	var list, slice = args[0], args[0].Go.String()
+
+	switch list.Type.(type) {
+	case types.List:
+	case types.Array:
+		slice += `[:]`
+	default:
+		return c.NewError("cannot sort " + list.String(c) + " in place")
+	}
+
+	less, err := before(c, list.Type.(compiler.Collection).Subtype(), args[1:]...)
+	if err != nil {
+		return err
+	}
+
+	c.Import("sort")
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, `func(list_ %v) { sort.SliceStable(list_, func(i_, j_ int) bool { var a_, b_ = list_[i_], list_[j_]; return %v }) }(%v)`,
+		types.List{}.With(c, list.Type.(compiler.Collection).Subtype()).Native(c), less.Go, slice)
+
+	return nil
+}
+
+//before returns a logical expression that is true when a comes before b, using the optional function or else the < operator.
+func before(c *compiler.Compiler, T compiler.Type, function ...compiler.Expression) (expression compiler.Expression, err error) {
+	var a, b = variable(c, "a_", T), variable(c, "b_", T)
+
+	if len(function) == 1 {
+		comparator, err := functionArgument(c, function[0])
+		if err != nil {
+			return expression, err
+		}
+
+		expression, err = comparator.Apply(c, function[0], a, b)
+		if err != nil {
+			return expression, err
+		}
+	} else {
+		ok, less, err := T.Operation(c, a, b, "<")
+		if err != nil {
+			return less, err
+		}
+		if !ok {
+			return less, c.NewError("cannot sort ", T.String(c), " without a function")
+		}
+		expression = less
+	}
+
+	if !expression.Equals(types.Logical{}) {
+		return expression, c.NewError("sort takes a function that returns a logical, not ", expression.String(c))
+	}
+
+	return expression, nil
+}
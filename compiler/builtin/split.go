@@ -0,0 +1,57 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Split splits a string into a list of strings around a separator, or around whitespace when there is no separator.
+type Split struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Split{})
+
+//Name returns split's name.
+func (Split) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `split`,
+	}
+}
+
+//Run does nothing.
+func (Split) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("split cannot be called as statement")
+}
+
+//Call calls split.
+func (Split) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) < 1 || len(args) > 2 || !allStrings(args...) {
+		return expression, c.NewError("split takes a string and an optional string separator")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.List{}.With(c, types.String{})
+	if len(args) == 1 {
+		fmt.Fprintf(&expression.Go, `strings.Fields(%v)`, args[0].Go)
+	} else {
+		fmt.Fprintf(&expression.Go, `strings.Split(%v, %v)`, args[0].Go, args[1].Go)
+	}
+
+	return expression, nil
+}
+
+//allStrings returns true if all of the arguments are strings.
+func allStrings(args ...compiler.Expression) bool {
+	for _, argument := range args {
+		if !argument.Equals(types.String{}) {
+			return false
+		}
+	}
+	return true
+}
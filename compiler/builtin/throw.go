@@ -0,0 +1,74 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Throw raises an error with a code and message, throw(2, "file not found").
+//Concepts that throw are marked as throwing, so that calls to them must be handled with ;
+type Throw struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Throw{})
+
+func init() {
+	compiler.ErrorValue = func(c *compiler.Compiler, native string) (expression compiler.Expression) {
+		expression = c.NewExpression()
+
+		var thing = compiler.Thing{
+			Fields: map[string]compiler.Field{
+				"code":    {Type: types.Integer{}, Index: 0},
+				"message": {Type: types.String{}, Index: 1},
+			},
+		}
+
+		expression.Type = thing
+		fmt.Fprintf(&expression.Go, `%v{code: I.NewInteger(int64(%v.Code)), message: %v.Message}`, thing.Native(c), native, native)
+		return
+	}
+}
+
+//Name returns throw's name.
+func (Throw) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `throw`,
+	}
+}
+
+//Call does nothing.
+func (Throw) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("throw cannot be called as an expression")
+}
+
+//Run throws the error, the code is 1 when it is left out.
+func (Throw) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	var code = "1"
+
+	switch len(args) {
+	case 1:
+	case 2:
+		if !args[0].Equals(types.Integer{}) {
+			return c.NewError("the error code must be an integer, not ", args[0].String(c))
+		}
+		code = fmt.Sprintf("int(%v.Int64())", args[0].Go)
+		args = args[1:]
+	default:
+		return c.NewError("throw takes a message and an optional integer code")
+	}
+
+	message, err := c.Cast(args[0], types.String{})
+	if err != nil {
+		return err
+	}
+
+	*c.Thrown = true
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, `ctx.Throw(%v, %v)`, code, message.Go)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Trim returns a string without leading and trailing whitespace, or without the leading and trailing symbols that are inside of a string.
+type Trim struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Trim{})
+
+//Name returns trim's name.
+func (Trim) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `trim`,
+	}
+}
+
+//Run does nothing.
+func (Trim) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("trim cannot be called as statement")
+}
+
+//Call calls trim.
+func (Trim) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) < 1 || len(args) > 2 || !allStrings(args...) {
+		return expression, c.NewError("trim takes a string and an optional string of symbols to trim")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	if len(args) == 1 {
+		fmt.Fprintf(&expression.Go, `strings.TrimSpace(%v)`, args[0].Go)
+	} else {
+		fmt.Fprintf(&expression.Go, `strings.Trim(%v, %v)`, args[0].Go, args[1].Go)
+	}
+
+	return expression, nil
+}
@@ -0,0 +1,51 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Upper returns a string with all of its symbols in upper case, or a symbol in upper case.
+type Upper struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Upper{})
+
+//Name returns upper's name.
+func (Upper) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `upper`,
+	}
+}
+
+//Run does nothing.
+func (Upper) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	return c.NewError("upper cannot be called as statement")
+}
+
+//Call calls upper.
+func (Upper) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(args) == 1 && args[0].Equals(types.Symbol{}) {
+		c.Import("unicode")
+
+		expression.Type = types.Symbol{}
+		fmt.Fprintf(&expression.Go, `unicode.ToUpper(%v)`, args[0].Go)
+		return expression, nil
+	}
+
+	if len(args) != 1 || !allStrings(args...) {
+		return expression, c.NewError("upper takes a string or a symbol")
+	}
+
+	c.Import("strings")
+
+	expression.Type = types.String{}
+	fmt.Fprintf(&expression.Go, `strings.ToUpper(%v)`, args[0].Go)
+
+	return expression, nil
+}
@@ -0,0 +1,35 @@
+package builtin
+
+import (
+	"github.com/qlova/viking/compiler"
+)
+
+//Wait waits for all of the concepts that were started with go to finish.
+type Wait struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterBuiltin(Wait{})
+
+//Name returns wait's name.
+func (Wait) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `wait`,
+	}
+}
+
+//Call does nothing.
+func (Wait) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	return expression, c.NewError("wait cannot be called as an expression")
+}
+
+//Run runs wait.
+func (Wait) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (err error) {
+	if len(args) != 0 {
+		return c.NewError("wait does not take any arguments")
+	}
+
+	c.Indent()
+	c.Go.WriteString(c.Goroutines() + ".Wait()")
+	return nil
+}
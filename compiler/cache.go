@@ -42,6 +42,7 @@ func (compiler *Compiler) CompileCacheWithContext(cache Cache, context Context)
 		}
 
 		compiler.Go.Write([]byte("\n"))
+		compiler.JS.Write([]byte("\n"))
 	}
 }
 
@@ -92,16 +93,28 @@ func (compiler *Compiler) CacheBlock() Cache {
 			compiler.Scan()
 		}
 
+		//Depths of the match statements inside of the block, each arm of a match is a block.
+		var matches []int
+
 		cache.LineNumber++
 		for {
 			var token = compiler.Scan()
 
 			if token.Is("\n") {
 				cache.Write(compiler.LastLine)
+
+				if len(matches) > 0 && depth == matches[len(matches)-1] {
+					if next := compiler.Peek(); !next.Is("}") && !next.Is("\n") && next != nil {
+						depth++
+					}
+				}
 			}
 
 			if token.Is(":") || token.Is("}") || token == nil {
 				depth--
+				if len(matches) > 0 && depth < matches[len(matches)-1] {
+					matches = matches[:len(matches)-1]
+				}
 				if depth == 0 {
 					if len(compiler.Line) > 0 {
 						cache.Write(compiler.Line[:len(compiler.Line)-1])
@@ -111,8 +124,18 @@ func (compiler *Compiler) CacheBlock() Cache {
 				}
 			} else {
 				switch token.String() {
-				case "for", "if", "catch", "try", "{", "main":
+				case "for", "if", "catch", "try", "{", "main", "defer":
 					depth++
+
+				case "match":
+					depth++
+					matches = append(matches, depth)
+
+				//Function literals.
+				case "function":
+					if compiler.Peek().Is("(") {
+						depth++
+					}
 				}
 
 			}
@@ -1,11 +1,30 @@
 package compiler
 
+//castingError is returned by types that do not know how to cast an expression, so that the other type can try.
+type castingError string
+
+func (err castingError) Error() string {
+	return string(err)
+}
+
 //CastingError returns an error when a type cannot be cast.
 func (compiler *Compiler) CastingError(from Expression, to Type) (Expression, error) {
-	return Expression{}, compiler.Unimplemented([]byte("casting " + from.Type.String(compiler) + " to " + to.String(compiler)))
+	return Expression{}, castingError("cannot cast " + from.Type.String(compiler) + " to " + to.String(compiler))
 }
 
 //Cast from expression to Type 'to'.
+//The type being cast from is asked first, then the type being cast to.
+//
+//	from \ to  integer  number  byte  symbol  string  logical  list.byte
+//	integer     -        yes     yes   yes     yes     yes      -
+//	number      yes      -       -     -       yes     yes      -
+//	byte        yes      yes     -     yes     yes     yes      -
+//	symbol      yes      -       yes   -       yes     -        yes
+//	string      yes      yes     -     -       yes     yes      yes
+//	logical     yes      yes     yes   -       yes     -        -
+//	list.byte   -        -       -     -       yes     -        -
+//
+//Any other value that can be printed (lists, arrays, tables and things) can be cast to a string.
 func (compiler *Compiler) Cast(from Expression, to Type) (Expression, error) {
 	if from.Equals(to) {
 		return from, nil
@@ -15,12 +34,15 @@ func (compiler *Compiler) Cast(from Expression, to Type) (Expression, error) {
 	if expression.Type == nil {
 		expression.Type = to
 	}
-	if err != nil {
+	if _, ok := err.(castingError); ok {
 		expression, err := to.Cast(compiler, from, to)
 		if expression.Type == nil {
 			expression.Type = to
 		}
+		if casting, ok := err.(castingError); ok {
+			return expression, compiler.NewError(string(casting))
+		}
 		return expression, err
 	}
-	return expression, nil
+	return expression, err
 }
@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -14,7 +15,7 @@ import (
 const Ilang = "github.com/qlova/i"
 
 //ReservedWords are not available for use as names.
-var ReservedWords = []string{"if", "for", "return", "break", "go", "in"}
+var ReservedWords = []string{"if", "for", "return", "break", "continue", "go", "in", "match", "defer", "constant"}
 
 //Set is a string set.
 type Set map[string]struct{}
@@ -60,6 +61,18 @@ type Compiler struct {
 	yield, callback chan bool
 
 	Main bool
+
+	//expansions counts the macros that have been expanded, so that the variables of each expansion have unique names.
+	expansions int
+
+	//expanding is the number of macro expansions that are being compiled inside of each other.
+	expanding int
+
+	//labels counts the Go labels that have been made, so that each label is unique.
+	labels int
+
+	//jumps counts the jumps out of deferred blocks, so that each jump has a unique code.
+	jumps int
 }
 
 //New returns a new initialised compiler.
@@ -72,9 +85,21 @@ func New() Compiler {
 //Init initialises the compiler.
 func (compiler *Compiler) Init() {
 	compiler.Functions = make(map[string]Type)
+	compiler.Throwing = make(Set)
+	compiler.Thrown = new(bool)
 	compiler.Concepts = make(map[string]Concept)
+	compiler.Things = make(map[string]Thing)
 	compiler.Aliases = make(map[string]Alias)
+	compiler.Constants = make(map[string]Constant)
 	compiler.Language = English
+	compiler.labels = 0
+	compiler.jumps = 0
+}
+
+//Label returns a new Go label with the given name, labels are numbered from the start of the compilation.
+func (compiler *Compiler) Label(name string) string {
+	compiler.labels++
+	return fmt.Sprintf("%v_%v", name, compiler.labels)
 }
 
 //NewScope creates and returns a new compiler scope.
@@ -129,6 +154,19 @@ type Scope struct {
 	Table    map[string]Type
 	Cleanups []func()
 	Afters   []func()
+
+	//Deferred blocks run in reverse order when the scope is left.
+	Deferred []*Deferred
+}
+
+//FunctionScope returns the index of the outermost scope of the function being compiled.
+func (compiler *Compiler) FunctionScope() int {
+	for i := len(compiler.Scope) - 1; i >= 0; i-- {
+		if _, ok := compiler.Scope[i].Table["flag_function"]; ok {
+			return i
+		}
+	}
+	return 0
 }
 
 //DeferCleanup schedules the function to run at the end of the current scope.
@@ -196,28 +234,21 @@ func (compiler *Compiler) DumpBufferHead(split []byte) {
 
 //LoseScope loses a scope level.
 func (compiler *Compiler) LoseScope() {
+	var scope = &compiler.Scope[len(compiler.Scope)-1]
 
-	//Cleanup
-	/*if compiler.InsideTypeDefinition {
-		compiler.Indent()
-		compiler.Go.Write(s("return " + compiler.TypeName.String() + "{}\n"))
-
-		//We need to create the Go code for this type definition.
-		var T = compiler.TypeDefinition
+	for len(scope.Deferred) > 0 {
+		var deferred = scope.Deferred[len(scope.Deferred)-1]
+		scope.Deferred = scope.Deferred[:len(scope.Deferred)-1]
 
-		compiler.Go.Neck.Write(s("type " + compiler.TypeName.String() + " struct {\n"))
-		compiler.Indent(&compiler.Go.Neck)
-		for _, field := range T.Fields {
-			compiler.Go.Neck.Write(s(field.Name + " "))
-			compiler.Go.Neck.Write(GoTypeOf(field.Type))
-			compiler.Go.Neck.Write(s("\n"))
+		if !deferred.native {
+			compiler.unwrap(deferred)
 		}
-		compiler.Go.Neck.Write(s("}\n\n"))
 
-		compiler.TypeDefinition = Type{}
-	}*/
+		compiler.JS.WriteString("} finally {")
+		compiler.JS.Write(deferred.JS)
+		compiler.JS.WriteString("}")
+	}
 
-	var scope = compiler.Scope[len(compiler.Scope)-1]
 	for _, cleanup := range scope.Cleanups {
 		cleanup()
 	}
@@ -434,6 +465,7 @@ func (compiler *Compiler) CompileReader(reader io.Reader) error {
 		}
 
 		compiler.Go.Write([]byte("\n"))
+		compiler.JS.Write([]byte("\n"))
 	}
 }
 
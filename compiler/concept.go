@@ -1,10 +1,44 @@
 package compiler
 
+import "errors"
+
 //Concept is a generic functions.
 type Concept struct {
 	Name      Token
 	Arguments []Argument
 	Cache
+
+	//Receiver is the named thing that this concept is a method of.
+	Receiver Type
+}
+
+//ScanConcept scans the arguments and block of a concept definition with the given name.
+func (compiler *Compiler) ScanConcept(name Token) (Concept, error) {
+	var concept = Concept{Name: name}
+
+	var arguments, err = compiler.ScanParameters()
+	if err != nil {
+		return concept, err
+	}
+	concept.Arguments = arguments
+
+	concept.Cache = compiler.CacheBlock()
+
+	return concept, nil
+}
+
+//ScanParameters scans the bracketed arguments of a concept or macro definition.
+func (compiler *Compiler) ScanParameters() ([]Argument, error) {
+	if !compiler.ScanIf('(') {
+		return nil, compiler.Expecting('(')
+	}
+
+	//Concept with multiple arguments.
+	if !compiler.ScanIf(')') {
+		return compiler.ScanArguments()
+	}
+
+	return nil, nil
 }
 
 //Generate generates and returns the name and return type of this function.
@@ -12,21 +46,34 @@ func (concept Concept) Generate(compiler *Compiler, args ...Expression) (name To
 	if compiler.Functions == nil {
 		compiler.Functions = make(map[string]Type)
 	}
+	if compiler.Throwing == nil {
+		compiler.Throwing = make(Set)
+	}
 
 	var id = concept.Name.String()
+	if Defined(concept.Receiver) {
+		id = concept.Receiver.String(compiler) + "." + id
+	}
 
 	if r, ok := compiler.Functions[id]; ok {
 		returns = r
 	} else if !ok {
 
+		var thrown bool
+
 		var context = compiler.NewContext()
 		context.Returns = &returns
+		context.Thrown = &thrown
 
 		//Simple case. A function with an unknown return value.
 		context.GainScope()
 
 		compiler.FlipBuffer()
 
+		if Defined(concept.Receiver) {
+			context.SetVariable(Token("this"), concept.Receiver)
+		}
+
 		for i, argument := range args {
 			if concept.Arguments[i].Variadic {
 				context.SetVariable(concept.Arguments[i].Token, Sequence{}.With(compiler, argument.Type))
@@ -43,6 +90,11 @@ func (concept Concept) Generate(compiler *Compiler, args ...Expression) (name To
 
 		//Build function definition.
 		FunctionHeader.Go.WriteString("func ")
+		if Defined(concept.Receiver) {
+			FunctionHeader.Go.WriteString("(this ")
+			FunctionHeader.Go.Write(compiler.GoTypeOf(concept.Receiver))
+			FunctionHeader.Go.WriteString(") ")
+		}
 		FunctionHeader.Go.Write(concept.Name)
 		FunctionHeader.Go.WriteString("(ctx I.Context")
 
@@ -63,19 +115,31 @@ func (concept Concept) Generate(compiler *Compiler, args ...Expression) (name To
 		FunctionHeader.Go.WriteString("{\n")
 
 		compiler.DumpBufferHead(FunctionHeader.Go.Bytes())
+
+		if thrown {
+			compiler.Throwing[id] = struct{}{}
+		}
 	}
-	compiler.Functions[concept.Name.String()] = returns
+	compiler.Functions[id] = returns
 
 	return Token(id), returns, nil
 }
 
 //Run runs a concept with the specified name wihout return values.
 func (concept Concept) Run(compiler *Compiler) error {
-	expression, err := concept.Call(compiler)
+	return compiler.runConcept(concept.call(compiler, Expression{}))
+}
+
+//RunMethod runs a concept as a method of this, wihout return values.
+func (concept Concept) RunMethod(compiler *Compiler, this Expression) error {
+	return compiler.runConcept(concept.call(compiler, this))
+}
+
+func (compiler *Compiler) runConcept(expression Expression, err error) error {
 	compiler.Indent()
 	compiler.Go.Write(expression.Go.Bytes())
 
-	if CompilerErr, ok := err.(Error); ok && CompilerErr.Message == errorConceptHasNoReturns {
+	if err == errConceptHasNoReturns {
 		return nil
 	}
 
@@ -84,8 +148,51 @@ func (concept Concept) Run(compiler *Compiler) error {
 
 //Call runs a concept with the specified name wihout return values.
 func (concept Concept) Call(compiler *Compiler) (Expression, error) {
+	return compiler.returning(concept.call(compiler, Expression{}))
+}
+
+//CallMethod calls a concept as a method of this.
+func (concept Concept) CallMethod(compiler *Compiler, this Expression) (Expression, error) {
+	return compiler.returning(concept.call(compiler, this))
+}
+
+//call scans the arguments and calls the concept.
+func (concept Concept) call(compiler *Compiler, this Expression) (Expression, error) {
+	var arguments, err = concept.scanArguments(compiler)
+	if err != nil {
+		return Expression{}, err
+	}
+
+	return compiler.generateAndCallConcept(concept, this, arguments)
+}
+
+//ScanCall scans the arguments passed to this concept.
+func (concept Concept) ScanCall(compiler *Compiler) ([]Expression, error) {
+	return concept.scanArguments(compiler)
+}
+
+//CallConcept calls the concept with the arguments, concepts without return values can be called.
+func (compiler *Compiler) CallConcept(concept Concept, this Expression, arguments []Expression) (Expression, error) {
+	var expression, err = compiler.generateAndCallConcept(concept, this, arguments)
+	if err == errConceptHasNoReturns {
+		return expression, nil
+	}
+	return expression, err
+}
+
+//returning reports calls to concepts without return values, they cannot be used in expressions.
+//The error is only created here, because creating it consumes the rest of the line.
+func (compiler *Compiler) returning(expression Expression, err error) (Expression, error) {
+	if err == errConceptHasNoReturns {
+		return expression, compiler.NewError(err.Error())
+	}
+	return expression, err
+}
+
+//scanArguments scans the arguments passed to this concept.
+func (concept Concept) scanArguments(compiler *Compiler) ([]Expression, error) {
 	if !compiler.ScanIf('(') {
-		return Expression{}, compiler.Expecting('(')
+		return nil, compiler.Expecting('(')
 	}
 
 	var Arguments = make([]Expression, len(concept.Arguments))
@@ -101,13 +208,14 @@ func (concept Concept) Call(compiler *Compiler) (Expression, error) {
 
 		var expression, err = compiler.ScanExpression()
 		if err != nil {
-			return Expression{}, err
+			return nil, err
 		}
 
 		if Defined(argument.Type) && !expression.Equals(argument.Type) {
+			var original = expression.Type
 			expression, err = compiler.Cast(expression, argument.Type)
 			if err != nil {
-				return Expression{}, compiler.NewError("type mismatch got type " + expression.Type.String(compiler) + " expecting type " + argument.Type.String(compiler))
+				return nil, compiler.NewError("type mismatch got type " + original.String(compiler) + " expecting type " + argument.Type.String(compiler))
 			}
 		}
 
@@ -125,7 +233,7 @@ func (concept Concept) Call(compiler *Compiler) (Expression, error) {
 				if compiler.Peek().Is(")") {
 					break
 				}
-				return Expression{}, compiler.Expecting(',')
+				return nil, compiler.Expecting(',')
 			}
 			if argument.Variadic {
 				goto variadic
@@ -134,25 +242,34 @@ func (concept Concept) Call(compiler *Compiler) (Expression, error) {
 	}
 
 	if !compiler.ScanIf(')') {
-		return Expression{}, compiler.Expecting(')')
+		return nil, compiler.Expecting(')')
 	}
 
-	return compiler.generateAndCallConcept(concept, Arguments)
+	return Arguments, nil
 }
 
-var errorConceptHasNoReturns = "function does not return any values and cannot be used in an expression"
+var errConceptHasNoReturns = errors.New("function does not return any values and cannot be used in an expression")
 
 //CallConcept calls a concept with the specified name.
-func (compiler *Compiler) generateAndCallConcept(concept Concept, arguments []Expression) (Expression, error) {
+func (compiler *Compiler) generateAndCallConcept(concept Concept, this Expression, arguments []Expression) (Expression, error) {
 
-	name, returns, err := concept.Generate(compiler, arguments...)
+	id, returns, err := concept.Generate(compiler, arguments...)
 	if err != nil {
 		return Expression{}, err
 	}
 
+	//Errors thrown by the concept need to be handled by the caller.
+	if compiler.Throwing.Get(id.String()) {
+		compiler.Throws = true
+	}
+
 	var expression = compiler.NewExpression()
 	expression.Type = returns
-	expression.Go.Write(name)
+	if Defined(concept.Receiver) {
+		expression.Go.WriteB(this.Go)
+		expression.Go.WriteString(".")
+	}
+	expression.Go.Write(concept.Name)
 	expression.Go.WriteString("(ctx")
 	for _, argument := range arguments {
 		expression.Go.WriteString(",")
@@ -161,7 +278,7 @@ func (compiler *Compiler) generateAndCallConcept(concept Concept, arguments []Ex
 	expression.Go.WriteString(")")
 
 	if !Defined(returns) {
-		return expression, compiler.NewError(errorConceptHasNoReturns)
+		return expression, errConceptHasNoReturns
 	}
 
 	return expression, nil
@@ -0,0 +1,52 @@
+package compiler
+
+//Constant is a typed value that was worked out at compile time, it cannot be assigned to.
+//The literal is used wherever the constant is named.
+//The value of the literal is the value of the constant at compile time, so that other constants can be worked out from it.
+type Constant struct {
+	Expression
+	Exported bool
+}
+
+//DefineConstant defines a new constant with the given literal, the literal's value must be known.
+func (compiler *Compiler) DefineConstant(name Token, literal Expression) error {
+	if _, ok := compiler.Constants[name.String()]; ok {
+		return compiler.NewError(name.String(), " is already a constant")
+	}
+	if Defined(compiler.GetVariable(name)) {
+		return compiler.NewError(name.String(), " is already a variable")
+	}
+
+	compiler.Constants[name.String()] = Constant{
+		Expression: literal,
+		Exported:   compiler.Export,
+	}
+	return nil
+}
+
+//Unshadowed returns an error when the name belongs to a constant, arguments and loop variables cannot hide constants.
+func (compiler *Compiler) Unshadowed(name Token, kind string) error {
+	if _, ok := compiler.Constants[name.String()]; ok {
+		return compiler.NewError(name.String(), " is a constant, it cannot be the name of ", kind)
+	}
+	return nil
+}
+
+//Constant returns the literal of the constant with the given name.
+func (compiler *Compiler) Constant(name Token) (expression Expression, ok bool) {
+	constant, ok := compiler.Constants[name.String()]
+	if !ok {
+		return expression, false
+	}
+	return constant.Literal(compiler), true
+}
+
+//Literal returns a copy of the constant's literal that can be written to.
+func (constant Constant) Literal(compiler *Compiler) Expression {
+	var expression = compiler.NewExpression()
+	expression.Type = constant.Type
+	expression.Go.Write(constant.Go.Bytes())
+	expression.JS.Write(constant.JS.Bytes())
+	expression.Value = constant.Value
+	return expression
+}
@@ -13,15 +13,16 @@ type Context struct {
 
 	Export bool
 
-	//Type definitions.
-	TypeName             Token
-	InsideTypeDefinition bool
-	TypeDefinition       Type
-
 	//Functions is the defined global functions available to this context.
 	Functions map[string]Type
 	Concepts  map[string]Concept
 
+	//Throwing is the set of generated functions that throw errors, calls to them must be handled.
+	Throwing Set
+
+	//Things is the defined named things available to this context.
+	Things map[string]Thing
+
 	Depth  int
 	Depths []int
 
@@ -30,19 +31,29 @@ type Context struct {
 
 	Aliases map[string]Alias
 
+	//Constants are typed values that were worked out at compile time.
+	Constants map[string]Constant
+
 	Returns *Type
 
 	//Does the current statement throw?
 	Throws bool
+
+	//Thrown is set when the current function throws an error.
+	Thrown *bool
 }
 
 //NewContext pushes a new context to the compiler.
 func (compiler *Compiler) NewContext() Context {
 	var ctx Context
 	ctx.Returns = new(Type)
+	ctx.Thrown = new(bool)
 	ctx.Concepts = compiler.Concepts
+	ctx.Things = compiler.Things
 	ctx.Functions = compiler.Functions
+	ctx.Throwing = compiler.Throwing
 	ctx.Aliases = compiler.Aliases
+	ctx.Constants = compiler.Constants
 	ctx.Directory = compiler.Directory
 	return ctx
 }
@@ -51,9 +62,13 @@ func (compiler *Compiler) NewContext() Context {
 func (compiler *Compiler) NewPackageContext() Context {
 	var ctx Context
 	ctx.Returns = new(Type)
+	ctx.Thrown = new(bool)
 	ctx.Concepts = make(map[string]Concept)
+	ctx.Things = make(map[string]Thing)
 	ctx.Functions = make(map[string]Type)
+	ctx.Throwing = make(Set)
 	ctx.Aliases = make(map[string]Alias)
+	ctx.Constants = make(map[string]Constant)
 	ctx.Directory = compiler.Directory
 	return ctx
 }
@@ -0,0 +1,138 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+)
+
+//Deferred is a deferred block, it is deferred natively in Go so that it also runs when the program panics.
+//Go defers to the end of the function, so inside of other blocks the rest of the block is wrapped in a function literal.
+type Deferred struct {
+	JS []byte
+
+	//native is set when the block is deferred by the function itself.
+	native bool
+
+	//jumps are the breaks, continues and returns that leave the function literal.
+	jumps []jump
+
+	//returns is set when a return value leaves the function literal.
+	returns bool
+}
+
+//jump is a break, continue or return that leaves a function literal, the literal returns the code and the jump is made after it.
+type jump struct {
+	code      int
+	statement string
+	value     bool
+
+	//scope is the index of the scope that the jump leaves to.
+	scope int
+}
+
+//Defer defers the Go code of a block to the end of the current scope.
+func (compiler *Compiler) Defer(Go, JS []byte) {
+	var scope = &compiler.Scope[len(compiler.Scope)-1]
+
+	var deferred = &Deferred{JS: JS, native: len(compiler.Scope)-1 == compiler.FunctionScope()}
+	scope.Deferred = append(scope.Deferred, deferred)
+
+	//The rest of the block is written to a new buffer, it is wrapped once the block ends.
+	if !deferred.native {
+		compiler.FlipBuffer()
+		compiler.Go.WriteString("\n")
+		compiler.Indent()
+	}
+
+	fmt.Fprintf(&compiler.Go, "defer func() %s()", bytes.TrimRight(Go, "\n"))
+	compiler.JS.WriteString("try {")
+}
+
+//Jump writes a break, continue or return statement that leaves the scopes down to the scope at index, the value is returned if it is not nil.
+func (compiler *Compiler) Jump(index int, statement string, value *Expression) {
+	var deferred = compiler.leaving(index)
+	if deferred == nil {
+		compiler.Go.WriteString(statement)
+		if value != nil {
+			fmt.Fprintf(&compiler.Go, " %v", value.Go)
+		}
+		return
+	}
+
+	compiler.jumps++
+	deferred.jumps = append(deferred.jumps, jump{code: compiler.jumps, statement: statement, value: value != nil, scope: index})
+
+	if value != nil {
+		deferred.returns = true
+		fmt.Fprintf(&compiler.Go, "jump_, value_ = %v, %v; return", compiler.jumps, value.Go)
+		return
+	}
+	fmt.Fprintf(&compiler.Go, "jump_ = %v; return", compiler.jumps)
+}
+
+//leaving returns the innermost function literal of a deferred block that is left by leaving the scopes down to the scope at index.
+func (compiler *Compiler) leaving(index int) *Deferred {
+	for i := len(compiler.Scope) - 1; i >= index && i >= 0; i-- {
+		var deferred = compiler.Scope[i].Deferred
+		for j := len(deferred) - 1; j >= 0; j-- {
+			if !deferred[j].native {
+				return deferred[j]
+			}
+		}
+	}
+	return nil
+}
+
+//unwrap wraps the rest of the block in the function literal of the deferred block and then makes the jumps that left it.
+func (compiler *Compiler) unwrap(deferred *Deferred) {
+	var js = append([]byte(nil), compiler.JS.Bytes()...)
+	var code = compiler.DumpAndReturnBuffer(nil)
+	compiler.JS.Write(js)
+
+	if len(deferred.jumps) == 0 {
+		compiler.Go.WriteString("func() {")
+		compiler.Go.Write(code)
+		compiler.Go.WriteString("\n")
+		compiler.Indent()
+		compiler.Go.WriteString("}()")
+		return
+	}
+
+	if deferred.returns {
+		fmt.Fprintf(&compiler.Go, "switch jumped_, returned_ := func() (jump_ int, value_ %s) {", compiler.GoTypeOf(*compiler.Returns))
+	} else {
+		compiler.Go.WriteString("switch jumped_ := func() (jump_ int) {")
+	}
+	compiler.Go.Write(code)
+	compiler.Go.WriteString("\n")
+	compiler.Indent()
+	compiler.Go.WriteString("return }(); jumped_ {")
+
+	for _, jump := range deferred.jumps {
+		compiler.Go.WriteString("\n")
+		compiler.Indent()
+		fmt.Fprintf(&compiler.Go, "case %v: ", jump.code)
+
+		//The jump leaves the function literals of outer deferred blocks too.
+		if outer := compiler.leaving(jump.scope); outer != nil {
+			outer.jumps = append(outer.jumps, jump)
+			if jump.value {
+				outer.returns = true
+				compiler.Go.WriteString("jump_, value_ = jumped_, returned_; return")
+			} else {
+				compiler.Go.WriteString("jump_ = jumped_; return")
+			}
+			continue
+		}
+
+		if jump.value {
+			fmt.Fprintf(&compiler.Go, "%v returned_", jump.statement)
+		} else {
+			compiler.Go.WriteString(jump.statement)
+		}
+	}
+
+	compiler.Go.WriteString("\n")
+	compiler.Indent()
+	compiler.Go.WriteString("}")
+}
@@ -1,10 +1,11 @@
 package compiler
 
 import (
-	"fmt"
+	"bytes"
 	"io"
 	"os"
 
+	"github.com/qlova/viking/compiler/scanner"
 	"github.com/qlova/viking/compiler/target"
 )
 
@@ -12,6 +13,9 @@ import (
 type Expression struct {
 	Type
 	target.Buffer
+
+	//Value is the value of the expression when it is known at compile time, integer literals are *big.Int
+	Value interface{}
 }
 
 func (compiler *Compiler) NewExpression() Expression {
@@ -39,6 +43,31 @@ func (compiler *Compiler) scanExpression() (Expression, error) {
 	return compiler.Expression(compiler.Scan())
 }
 
+//ExpressionOf scans an expression out of the provided code, such as the code inside of a string interpolation.
+func (compiler *Compiler) ExpressionOf(code []byte) (Expression, error) {
+	var saved = compiler.Scanner
+	defer func() {
+		compiler.Scanner = saved
+	}()
+
+	compiler.Scanner = scanner.Scanner{
+		Filename:   saved.Filename,
+		LineNumber: saved.LineNumber,
+	}
+	compiler.SetReader(bytes.NewReader(code))
+
+	expression, err := compiler.ScanExpression()
+	if err != nil {
+		return expression, err
+	}
+
+	if next := compiler.Peek(); next != nil {
+		return expression, compiler.NewError("unexpected " + next.String())
+	}
+
+	return expression, nil
+}
+
 //Expression acts like ScanExpression but without the shunting.
 func (compiler *Compiler) Expression(token Token) (Expression, error) {
 	var expression = compiler.NewExpression()
@@ -54,7 +83,7 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 	}
 
 	//Inverse expression.
-	if token.Is("-") {
+	if token.Is("-") && !compiler.Peek().Is("]") {
 		var expression, err = compiler.scanExpression()
 		ok, expression, err := expression.Type.Operation(compiler, compiler.NewExpression(), expression, "-")
 		if err != nil {
@@ -68,6 +97,9 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 
 	//Alias expression.
 	if alias, ok := compiler.Aliases[token.String()]; ok {
+		if alias.Macro {
+			return compiler.ExpandExpression(alias)
+		}
 		compiler.UnpackAlias(alias)
 		return compiler.Expression(compiler.Scan())
 	}
@@ -76,6 +108,7 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 	if variable := compiler.GetVariable(token); Defined(variable) {
 		expression.Type = variable
 		expression.Go.Write(token)
+		expression.JS.Write(token)
 
 		if compiler.Peek().Is("[") {
 			if collection, ok := variable.(Collection); ok {
@@ -92,6 +125,22 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 		return expression, nil
 	}
 
+	//Constant expression, variables and arguments are found first.
+	if constant, ok := compiler.Constant(token); ok {
+		if compiler.Peek().Is("[") {
+			if collection, ok := constant.Type.(Collection); ok {
+				var args, err = compiler.Indicies()
+				if err != nil {
+					return constant, err
+				}
+
+				return collection.Index(compiler, constant, args...)
+			}
+			return constant, compiler.NewError("Unexpected [, type is not indexable")
+		}
+		return constant, nil
+	}
+
 	for _, T := range Types {
 		if T.Expression != nil {
 			ok, expression, err := T.Expression(compiler)
@@ -114,9 +163,13 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 			return internal, compiler.Expecting(')')
 		}
 		expression.Type = internal.Type
+		expression.Value = internal.Value
 		expression.Go.Write(token)
 		expression.Go.Write(internal.Go.Bytes())
 		expression.Go.WriteString(")")
+		expression.JS.Write(token)
+		expression.JS.Write(internal.JS.Bytes())
+		expression.JS.WriteString(")")
 		return expression, nil
 	}
 
@@ -128,7 +181,6 @@ func (compiler *Compiler) Expression(token Token) (Expression, error) {
 		}
 
 		if collection, ok := subject.Type.(Collection); ok {
-			fmt.Println(collection.Length(compiler, subject).String(compiler))
 			return collection.Length(compiler, subject), nil
 		}
 		return Expression{}, compiler.NewError("cannot take the length of " + subject.String(compiler))
@@ -0,0 +1,16 @@
+package compiler
+
+//goroutines declares the wait group that counts the running goroutines.
+const goroutines = "var goroutines sync.WaitGroup\n"
+
+//Goroutines returns the name of the wait group that counts the running goroutines, the first call declares it.
+func (compiler *Compiler) Goroutines() string {
+	compiler.Import("sync")
+	compiler.Require(goroutines)
+	return "goroutines"
+}
+
+//Concurrent returns true if the program runs goroutines.
+func (compiler *Compiler) Concurrent() bool {
+	return compiler.Dependencies.Get(goroutines)
+}
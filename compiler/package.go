@@ -43,6 +43,13 @@ func (P Package) Expression(compiler *Compiler) (Expression, error) {
 
 	var token = compiler.Scan()
 
+	if constant, ok := P.Constants[token.String()]; ok {
+		if !constant.Exported {
+			return Expression{}, compiler.NewError(token.String(), " is not exported")
+		}
+		return constant.Literal(compiler), nil
+	}
+
 	concept, ok := P.Concepts[token.String()]
 	if !ok {
 		return Expression{}, compiler.Undefined(token)
@@ -0,0 +1,41 @@
+package compiler
+
+import "fmt"
+
+//Range is the type of a range index, a to b, either end of the range can be left open.
+//Both ends are inclusive and wrap around like any other index, so negative indices count from the end.
+type Range struct {
+	Nothing
+
+	From, To Expression
+}
+
+//Name returns the name of this type.
+func (Range) Name() String {
+	return String{
+		English: `range`,
+	}
+}
+
+func (Range) String(c *Compiler) string {
+	return Range{}.Name()[c.Language]
+}
+
+//Equals returns true if the other type is equal to this type.
+func (Range) Equals(other Type) bool {
+	_, ok := other.(Range)
+	return ok
+}
+
+//Slice returns a function literal that slices the native collection by the range, the result shares elements with the collection.
+//native must be a Go slice type, the function literal must be called with the collection.
+func (r Range) Slice(c *Compiler, native Token) string {
+	var code = fmt.Sprintf(`func(collection %v) %v { var from, to = 0, len(collection)-1; `, native, native)
+	if r.From.Type != nil {
+		code += fmt.Sprintf(`from = I.IndexList(%v, len(collection)); `, r.From.Go)
+	}
+	if r.To.Type != nil {
+		code += fmt.Sprintf(`to = I.IndexList(%v, len(collection)); `, r.To.Go)
+	}
+	return code + `if to < from { return collection[:0] }; return collection[from : to+1 : to+1] }`
+}
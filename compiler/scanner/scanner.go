@@ -111,26 +111,80 @@ func (scanner *Scanner) readByte() error {
 	return scanner.readByteRaw(false)
 }
 
-func (scanner *Scanner) readString() ([]byte, error) {
+//readString reads a string up to its endquote.
+//Escaped characters are kept as they are and strings nested inside of {interpolations} are read whole.
+//Strings that are not closed by the end of the line are returned as unclosed tokens.
+func (scanner *Scanner) readString() []byte {
 	var result = []byte{'"'}
 
+	var depth int
+
 	for {
-		b, err := scanner.Reader.Peek(1)
-		if err != nil {
-			return nil, err
+		var b = scanner.lookahead(0)
+		if b == 0 || b == '\n' {
+			return append(result, unclosed)
 		}
 
 		if err := scanner.readByte(); err != nil {
-			return nil, err
+			return append(result, unclosed)
 		}
 
-		result = append(result, b[0])
-		if b[0] == '"' {
-			break
+		result = append(result, b)
+
+		switch b {
+		case '\\':
+			var escaped = scanner.lookahead(0)
+			if escaped == 0 || escaped == '\n' {
+				return append(result, unclosed)
+			}
+			if err := scanner.readByte(); err != nil {
+				return append(result, unclosed)
+			}
+			result = append(result, escaped)
+
+		case '{':
+			if scanner.interpolation() {
+				depth++
+			}
+
+		case '}':
+			if depth > 0 {
+				depth--
+			}
+
+		case '"':
+			if depth == 0 {
+				return result
+			}
+
+			var nested = scanner.readString()
+			result = append(result, nested[1:]...)
+			if Token(nested).Unclosed() {
+				return result
+			}
 		}
 	}
+}
 
-	return result, nil
+//interpolation returns true if the { that was just read opens an interpolation, the } that closes it must be on the same line.
+//Strings nested inside of the interpolation escape their literal braces, so the braces are balanced.
+func (scanner *Scanner) interpolation() bool {
+	var depth = 1
+	for offset := 0; ; offset++ {
+		switch scanner.lookahead(offset) {
+		case 0, '\n':
+			return false
+		case '\\':
+			offset++
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
 }
 
 func (scanner *Scanner) readLine() ([]byte, error) {
@@ -202,14 +256,68 @@ func (scanner *Scanner) readLiteral() ([]byte, error) {
 func (scanner *Scanner) readNumber() ([]byte, error) {
 	var result = []byte{}
 
+	var point, exponent bool
+
 	for {
 		b, err := scanner.Reader.Peek(1)
 		if err != nil {
 			return result, err
 		}
 
+		var hexadecimal = len(result) > 1 && result[0] == '0' && (result[1] == 'x' || result[1] == 'X')
+
 		switch b[0] {
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'x':
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+
+		//Base prefixes, 0b1010 0o17 0xFF
+		case 'x', 'X', 'o', 'O':
+			if len(result) != 1 || result[0] != '0' {
+				return result, nil
+			}
+
+		//Hexadecimal digits, 0xFF
+		case 'a', 'b', 'c', 'd', 'f', 'A', 'B', 'C', 'D', 'F':
+			var prefix = (b[0] == 'b' || b[0] == 'B') && len(result) == 1 && result[0] == '0'
+			if !hexadecimal && !prefix {
+				return result, nil
+			}
+
+		//Digit separators, 1_000_000
+		case '_':
+			var next = scanner.lookahead(1)
+			if point || exponent || !(isDigit(next) || hexadecimal && isHex(next)) {
+				return result, nil
+			}
+
+		//Decimal point, 1.5
+		case '.':
+			if point || exponent || hexadecimal || !isDigit(scanner.lookahead(1)) {
+				return result, nil
+			}
+			point = true
+
+		//Scientific notation, 1e10 1.5e-3
+		case 'e', 'E':
+			if hexadecimal {
+				break
+			}
+			if exponent {
+				return result, nil
+			}
+			if sign := scanner.lookahead(1); sign == '+' || sign == '-' {
+				if !isDigit(scanner.lookahead(2)) {
+					return result, nil
+				}
+				if err := scanner.readByte(); err != nil {
+					return result, err
+				}
+				result = append(result, b[0])
+				b = []byte{sign}
+			} else if !isDigit(sign) {
+				return result, nil
+			}
+			exponent = true
+
 		default:
 			return result, nil
 		}
@@ -222,6 +330,23 @@ func (scanner *Scanner) readNumber() ([]byte, error) {
 	}
 }
 
+//lookahead returns the byte that is offset bytes ahead of the scanner, or zero.
+func (scanner *Scanner) lookahead(offset int) byte {
+	b, err := scanner.Reader.Peek(offset + 1)
+	if err != nil {
+		return 0
+	}
+	return b[offset]
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+func isHex(b byte) bool {
+	return isDigit(b) || b >= 'a' && b <= 'f' || b >= 'A' && b <= 'F'
+}
+
 func (scanner *Scanner) scan() Token {
 	var token Token
 
@@ -278,6 +403,14 @@ func (scanner *Scanner) scan() Token {
 					return token
 				}
 
+				//Shift operators, << and >>
+				if (peek[0] == '<' || peek[0] == '>') && scanner.lookahead(0) == peek[0] {
+					if err := scanner.readByte(); err != nil {
+						return token
+					}
+					return Token{peek[0], peek[0]}
+				}
+
 				return Token{peek[0]}
 
 			//Quotes
@@ -289,12 +422,8 @@ func (scanner *Scanner) scan() Token {
 				if err := scanner.readByte(); err != nil {
 					return token
 				}
-				s, err := scanner.readString()
-				if err != nil {
-					return token
-				}
 
-				return s
+				return scanner.readString()
 
 			//Literals
 			case '`':
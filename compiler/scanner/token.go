@@ -10,6 +10,14 @@ func (token Token) String() string {
 	return string(token)
 }
 
+//unclosed marks the end of a string token that was not closed, a newline cannot be inside of a string token otherwise.
+const unclosed = '\n'
+
+//Unclosed returns true if the token is a string that was not closed before the end of its line.
+func (token Token) Unclosed() bool {
+	return len(token) > 0 && token[0] == '"' && token[len(token)-1] == unclosed
+}
+
 //Is can be used to comare tokens for equality.
 func (token Token) Is(constant string) bool {
 	return bytes.Equal(token, []byte(constant))
@@ -22,6 +22,11 @@ var SequenceLength = func(c *Compiler, this Expression) (expression Expression)
 	panic("unitialised sequence length function")
 }
 
+//SequenceSlice returns a slice of the sequence as a list.
+var SequenceSlice = func(c *Compiler, this Expression, r Range) (Expression, error) {
+	panic("unitialised sequence slice function")
+}
+
 //Subtype returns the subtype.
 func (sequence Sequence) Subtype() Type {
 	return sequence.subtype
@@ -194,6 +199,10 @@ func (arguments Sequence) Index(c *Compiler, this Expression, indices ...Express
 	}
 	var index = indices[0]
 
+	if r, ok := index.Type.(Range); ok {
+		return SequenceSlice(c, this, r)
+	}
+
 	expression.Type = arguments.Subtype()
 	expression.Go.WriteB(this.Go)
 	expression.Go.WriteString(`[I.IndexList(`)
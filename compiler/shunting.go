@@ -21,7 +21,7 @@ func Precedence(symbol []byte) int {
 	case "+", "-":
 		return 3
 
-	case "*", "/", `%`:
+	case "*", "/", `%`, "<<", ">>":
 		return 4
 
 	case "^":
@@ -42,11 +42,21 @@ func Precedence(symbol []byte) int {
 func (compiler *Compiler) Shunt(expression Expression, precedence int) (result Expression, err error) {
 	result = expression
 
-	//Callables.
+	//Fields and methods.
 	if compiler.Peek().Is(".") {
 		if thing, ok := result.Type.(Thing); ok {
 			compiler.Scan()
-			return thing.Index(compiler, result, compiler.Scan())
+
+			var name = compiler.Scan()
+			if method, ok := thing.Methods[name.String()]; ok {
+				result, err = method.CallMethod(compiler, result)
+			} else {
+				result, err = thing.Index(compiler, result, name)
+			}
+			if err != nil {
+				return result, err
+			}
+			return compiler.Shunt(result, precedence)
 		}
 		return result, compiler.NewError("Cannot index ", result.Type.String(compiler))
 	}
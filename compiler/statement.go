@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 
@@ -17,6 +18,16 @@ func RegisterStatement(statement Statement) Statement {
 	return statement
 }
 
+//ErrorValue returns the value of the error variable inside of for errors, from the native runtime error.
+var ErrorValue = func(c *Compiler, native string) Expression {
+	panic("unitialised error value function")
+}
+
+//LoopLabel returns the Go label of the innermost loop and the index of its scope, so that the break tag can leave the loop.
+var LoopLabel = func(c *Compiler) (label string, scope int, err error) {
+	panic("unitialised loop label function")
+}
+
 //Statement is an 'i' language statement.
 type Statement interface {
 	Name() String
@@ -35,18 +46,28 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 
 			switch compiler.Scan().String() {
 			case "break":
-				compiler.Go.WriteString("; if (len(ctx.Errors()) > 0) { break }")
+				label, loop, err := LoopLabel(compiler)
+				if err != nil {
+					*returning = err
+					return
+				}
+				compiler.Go.WriteString("; if (len(ctx.Errors()) > 0) { ")
+				compiler.Jump(loop, "break "+label, nil)
+				compiler.Go.WriteString(" }")
 			case "ignore":
 				compiler.Go.WriteString("; ctx.Errors()")
+			case "throw":
+				//The errors are left for the caller to handle.
+				*compiler.Thrown = true
 			case "for":
 				if !compiler.Scan().Is("errors") {
 					*returning = compiler.NewError("do you mean for errors?")
 					return
 				}
-				compiler.Go.WriteString("; for i, error := range ctx.Errors() {")
+				var value = ErrorValue(compiler, "error_")
+				fmt.Fprintf(&compiler.Go, "; for _, error_ := range ctx.Errors() { var error = %v; _ = error\n", value.Go)
 				compiler.GainScope()
-				//compiler.SetVariable(s("i"), Integer)
-				compiler.SetVariable(s("error"), Nothing{})
+				compiler.SetVariable(s("error"), value.Type)
 				*returning = compiler.CompileBlock()
 				return
 			default:
@@ -118,10 +139,18 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 
 	//Return statement.
 	case "return":
+		if compiler.Flag(s("defer")) {
+			return compiler.NewError("cannot return from a deferred block")
+		}
+
+		var function = compiler.FunctionScope()
+		if compiler.Parallel() >= function {
+			return compiler.NewError("cannot return from a parallel loop")
+		}
 		compiler.Indent()
-		compiler.Go.WriteString("return ")
 
 		if compiler.Peek().Is("\n") {
+			compiler.Jump(function, "return", nil)
 			return nil
 		}
 
@@ -132,7 +161,7 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 
 		*compiler.Returns = expression.Type
 
-		compiler.Go.Write(expression.Go.Bytes())
+		compiler.Jump(function, "return", &expression)
 		return nil
 
 	//Close block.
@@ -205,6 +234,7 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 		var expression = compiler.NewExpression()
 		expression.Type = T
 		expression.Go.Write(token)
+		expression.JS.Write(token)
 
 		if runnable, ok := T.(Runnable); ok && compiler.Peek().Is("(") {
 
@@ -217,6 +247,16 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 
 			return runnable.Run(compiler, expression, args...)
 		}
+
+		//Methods and fields.
+		if thing, ok := T.(Thing); ok && compiler.Peek().Is(".") {
+			return thing.Statement(compiler, expression)
+		}
+	}
+
+	//Macros.
+	if alias, ok := compiler.Aliases[token.String()]; ok && alias.Macro {
+		return compiler.ExpandStatements(alias)
 	}
 
 	//Aliases.
@@ -229,6 +269,11 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 	if compiler.Peek().Is("[") {
 
 		variable := compiler.GetVariable(token)
+
+		if _, ok := compiler.Constants[token.String()]; ok && !Defined(variable) {
+			return compiler.NewError("cannot modify " + token.String() + ", it is a constant")
+		}
+
 		if !Defined(variable) {
 			return compiler.Undefined(token)
 		}
@@ -238,6 +283,10 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 			return compiler.NewError("cannot index " + token.String() + ", not a collection type")
 		}
 
+		if compiler.Shared(token) {
+			return compiler.NewError("cannot modify " + token.String() + " inside of a parallel loop")
+		}
+
 		var indicies, err = compiler.Indicies()
 		if err != nil {
 			return err
@@ -246,6 +295,7 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 		var expression = compiler.NewExpression()
 		expression.Type = variable
 		expression.Go.Write(token)
+		expression.JS.Write(token)
 
 		if !compiler.ScanIf('$') {
 			return compiler.Expecting('$')
@@ -266,8 +316,11 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 	//Variables.
 	if compiler.ScanIf('$') {
 		if compiler.ScanIf('=') {
+			if compiler.Shared(token) {
+				return compiler.NewError("cannot modify " + token.String() + " inside of a parallel loop, unless it is a reduction")
+			}
 			compiler.Indent()
-			if Defined(compiler.GetVariable(token)) {
+			if _, ok := compiler.Constants[token.String()]; ok || Defined(compiler.GetVariable(token)) {
 				return compiler.AssignVariable(token)
 			}
 			return compiler.DefineVariable(token)
@@ -323,46 +376,34 @@ func (compiler *Compiler) CompileStatement() (returning error) {
 			compiler.Concepts = make(map[string]Concept)
 		}
 
-		//Function definition?
-		if compiler.ScanIf('(') {
-
-			//Concept with multiple arguments.
-			if !compiler.ScanIf(')') {
-
-				var arguments, err = compiler.ScanArguments()
-				if err != nil {
-					return err
-				}
-
-				var cache = compiler.CacheBlock()
-
-				compiler.Concepts[token.String()] = Concept{
-					Cache:     cache,
-					Name:      token,
-					Arguments: arguments,
-				}
+		//Method definition?
+		if thing, ok := compiler.Things[token.String()]; ok && compiler.ScanIf('.') {
+			return thing.DefineMethod(compiler, compiler.Scan())
+		}
 
-				return nil
+		//Function definition?
+		if compiler.Peek().Is("(") {
+			var arguments, err = compiler.ScanParameters()
+			if err != nil {
+				return err
 			}
 
-			var cache = compiler.CacheBlock()
+			//Macro definition, name(a, b) = a + b
+			if compiler.ScanIf('=') {
+				return compiler.DefineMacro(token, arguments)
+			}
 
 			compiler.Concepts[token.String()] = Concept{
-				Cache: cache,
-				Name:  token,
+				Name:      token,
+				Arguments: arguments,
+				Cache:     compiler.CacheBlock(),
 			}
 
 			return nil
 		}
 
-		//Assuming type definition.
-		compiler.TypeName = token
-		compiler.InsideTypeDefinition = true
-
-		compiler.Go.Write(s("func New" + token.String() + "() " + token.String() + " {\n"))
-
-		compiler.GainScope()
-		return compiler.CompileBlock()
+		//Assuming thing definition.
+		return compiler.DefineThing(token)
 	}
 
 	return compiler.Undefined(s("statement: " + token.String()))
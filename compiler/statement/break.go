@@ -0,0 +1,73 @@
+package statement
+
+import (
+	"github.com/qlova/viking/compiler"
+)
+
+//Break is a break statement, it stops the current loop or the loop with the given name.
+type Break struct{}
+
+var _ = compiler.RegisterStatement(Break{})
+
+//Name returns the name of this statement.
+func (Break) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `break`,
+	}
+}
+
+//Compile compiles this statement.
+func (Break) Compile(c *compiler.Compiler) error {
+	return jump(c, "break")
+}
+
+func init() {
+	compiler.LoopLabel = func(c *compiler.Compiler) (string, int, error) {
+		loop, err := leave(c, "break", "loop")
+		return loop.Label, loop.scope, err
+	}
+}
+
+//jump compiles a break or continue statement, which can name an outer loop, break row
+//Jumps always use the label of the loop, so that a break inside of a match leaves the loop.
+func jump(c *compiler.Compiler, statement string) error {
+	var name = "loop"
+	if !c.Peek().Is("\n") && !c.Peek().Is("}") {
+		name = "loop_" + c.Scan().String()
+	}
+
+	loop, err := leave(c, statement, name)
+	if err != nil {
+		return err
+	}
+
+	c.Indent()
+	c.Jump(loop.scope, statement+" "+loop.Label, nil)
+
+	return nil
+}
+
+//leave returns the loop that a break or continue refers to and marks its label as used.
+func leave(c *compiler.Compiler, statement, name string) (Loop, error) {
+	if !c.Flag(compiler.Token("for")) {
+		return Loop{}, c.NewError(statement, " must be inside of a loop")
+	}
+
+	loop, ok := c.GetVariable(compiler.Token(name)).(Loop)
+	if !ok {
+		return Loop{}, c.NewError("there is no loop named ", c.LastToken.String())
+	}
+	*loop.used = true
+
+	if parallel := c.Parallel(); parallel >= 0 && (loop.scope < parallel || (loop.scope == parallel && statement == "break")) {
+		return Loop{}, c.NewError("cannot ", statement, " out of a parallel loop")
+	}
+
+	for i := len(c.Scope) - 1; i > loop.scope; i-- {
+		if _, ok := c.Scope[i].Table["flag_defer"]; ok {
+			return Loop{}, c.NewError("cannot ", statement, " out of a deferred block")
+		}
+	}
+
+	return loop, nil
+}
@@ -0,0 +1,53 @@
+package statement
+
+import (
+	"unicode"
+
+	"github.com/qlova/viking/compiler"
+)
+
+//Constant defines a typed value that is worked out at compile time and cannot be changed.
+//Constants can be used as the size of an array and are exported from packages with the . tag.
+//Pure concepts are evaluated at compile time, so that tables can be inlined into the output.
+//
+//	constant size = 2 * 8
+//	grid $= array[size].integer()
+//	constant primes = sieve(100)
+type Constant struct{}
+
+var _ = compiler.RegisterStatement(Constant{})
+
+//Name returns the name of this statement.
+func (Constant) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `constant`,
+	}
+}
+
+//Compile compiles this statement.
+func (Constant) Compile(c *compiler.Compiler) error {
+	var name = c.Scan()
+	if name == nil || !unicode.IsLetter(rune(name[0])) {
+		return c.NewError("expecting the name of the constant")
+	}
+	for _, word := range compiler.ReservedWords {
+		if name.Is(word) {
+			return c.NewError(word, " cannot be the name of a constant")
+		}
+	}
+
+	if !c.ScanIf('=') {
+		return c.Expecting('=')
+	}
+
+	value, err := evaluate(c)
+	if err != nil {
+		return err
+	}
+
+	if next := c.Peek(); !next.Is("\n") && next != nil {
+		return c.NewError("unexpected ", next.String(), " after the value of ", name.String())
+	}
+
+	return c.DefineConstant(name, literal(c, value))
+}
@@ -0,0 +1,20 @@
+package statement
+
+import "github.com/qlova/viking/compiler"
+
+//Continue is a continue statement, it skips to the next iteration of the current loop or the loop with the given name.
+type Continue struct{}
+
+var _ = compiler.RegisterStatement(Continue{})
+
+//Name returns the name of this statement.
+func (Continue) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `continue`,
+	}
+}
+
+//Compile compiles this statement.
+func (Continue) Compile(c *compiler.Compiler) error {
+	return jump(c, "continue")
+}
@@ -0,0 +1,51 @@
+package statement
+
+import (
+	"github.com/qlova/viking/compiler"
+)
+
+//Defer is a defer statement, the block runs when the enclosing block is left, at its end, by a return or break or by a panic.
+//
+//	defer: print("closed")
+type Defer struct{}
+
+var _ = compiler.RegisterStatement(Defer{})
+
+//Name returns the name of this statement.
+func (Defer) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `defer`,
+	}
+}
+
+//Compile compiles this statement.
+func (Defer) Compile(c *compiler.Compiler) error {
+	if len(c.Scope) == 0 {
+		return c.NewError("defer must be inside of a block")
+	}
+
+	var cache = c.CacheBlock()
+
+	var context = c.NewContext()
+	context.Returns = c.Returns
+	context.Thrown = c.Thrown
+	context.Depth = c.Depth
+	context.Scope = append([]compiler.Scope(nil), c.Scope...)
+	context.GainScope()
+	context.SetFlag(compiler.Token("defer"))
+
+	//The block is compiled here, so that it refers to the variables that are in scope.
+	c.FlipBuffer()
+	c.Go.WriteString("{")
+	c.JS.WriteString("{")
+	if err := c.CompileCacheWithContext(cache, context); err != nil {
+		c.DumpBuffer(nil)
+		return err
+	}
+	var js = append([]byte(nil), c.JS.Bytes()...)
+	var code = c.DumpAndReturnBuffer(nil)
+
+	c.Defer(code, js)
+
+	return nil
+}
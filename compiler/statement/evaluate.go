@@ -0,0 +1,721 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/scanner"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//value is a value that is known at compile time, an integer (*big.Int), number (*big.Rat), string, logical (bool) or collection (*collection).
+type value interface{}
+
+//collection is a list, array or sequence that is known at compile time, the values are laid out like the native slice.
+type collection struct {
+	Type   compiler.Type
+	values []value
+}
+
+//evaluator works out values at compile time, inside of a concept it has the concept's variables.
+type evaluator struct {
+	*compiler.Compiler
+
+	variables map[string]value
+
+	//returned is the value of the return statement that stopped the concept.
+	returned value
+
+	//label is the name of the loop that a break or continue stopped.
+	label string
+
+	//depth is the number of concepts that are being evaluated, steps is shared by all of them.
+	depth int
+	steps *int
+}
+
+//evaluate scans an expression and works out its value at compile time.
+func evaluate(c *compiler.Compiler) (value, error) {
+	var e = evaluator{Compiler: c, steps: new(int)}
+	return e.expression()
+}
+
+//expression scans an expression.
+func (e *evaluator) expression() (value, error) {
+	return e.expressionOf(e.Scan())
+}
+
+//expressionOf scans an expression that starts with the token.
+func (e *evaluator) expressionOf(token compiler.Token) (value, error) {
+	operand, err := e.operandOf(token)
+	if err != nil {
+		return nil, err
+	}
+	return e.shunt(operand, 0)
+}
+
+//shunt applies the operators that follow a value, like Compiler.Shunt.
+func (e *evaluator) shunt(result value, precedence int) (value, error) {
+	for peek := e.Peek(); compiler.Precedence(peek) >= precedence; peek = e.Peek() {
+		var symbol = e.Scan()
+
+		rhs, err := e.operand()
+		if err != nil {
+			return nil, err
+		}
+
+		for compiler.Precedence(e.Peek()) > compiler.Precedence(symbol) {
+			rhs, err = e.shunt(rhs, compiler.Precedence(e.Peek()))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result, err = operate(e.Compiler, result, rhs, symbol.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+//operand scans a value without the operators that follow it.
+func (e *evaluator) operand() (value, error) {
+	return e.operandOf(e.Scan())
+}
+
+//operandOf scans a literal, variable, constant, call, sub-expression or inverted value, followed by any indices.
+func (e *evaluator) operandOf(token compiler.Token) (value, error) {
+	result, err := e.atom(token)
+	if err != nil {
+		return nil, err
+	}
+
+	for e.Peek().Is("[") {
+		e.Scan()
+		index, err := e.expression()
+		if err != nil {
+			return nil, err
+		}
+		if !e.ScanIf(']') {
+			return nil, e.Expecting(']')
+		}
+		result, err = e.index(result, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (e *evaluator) atom(token compiler.Token) (value, error) {
+	if token == nil || token.Is("\n") {
+		return nil, e.NewError("expecting a value")
+	}
+
+	switch {
+	case token.Is("("):
+		inner, err := e.expression()
+		if err != nil {
+			return nil, err
+		}
+		if !e.ScanIf(')') {
+			return nil, e.Expecting(')')
+		}
+		return inner, nil
+
+	case token.Is("-"):
+		operand, err := e.operand()
+		if err != nil {
+			return nil, err
+		}
+		return operate(e.Compiler, big.NewInt(0), operand, "-")
+
+	case token.Is("!"):
+		operand, err := e.operand()
+		if err != nil {
+			return nil, err
+		}
+		if logical, ok := operand.(bool); ok {
+			return !logical, nil
+		}
+		return nil, e.NewError("cannot apply not operator to value of type ", kind(e.Compiler, operand))
+
+	case token.Is("#"):
+		operand, err := e.operand()
+		if err != nil {
+			return nil, err
+		}
+		switch operand := operand.(type) {
+		case string:
+			return big.NewInt(int64(len(operand))), nil
+		case *collection:
+			return big.NewInt(int64(len(operand.values))), nil
+		}
+		return nil, e.NewError("cannot take the length of ", kind(e.Compiler, operand))
+
+	case token.Is("["):
+		return e.sequence()
+
+	case token.Is("if"):
+		return e.conditional()
+
+	case token.Is("true"), token.Is("false"):
+		return token.Is("true"), nil
+
+	case token[0] == '"':
+		return e.unquote(token)
+
+	case token[0] >= '0' && token[0] <= '9':
+		var literal = strings.Replace(token.String(), "_", "", -1)
+		if len(literal) > 1 && literal[0] == '0' && strings.ContainsRune("bBoOxX", rune(literal[1])) {
+			if integer, ok := new(big.Int).SetString(literal, 0); ok {
+				return integer, nil
+			}
+		} else if integer, ok := new(big.Int).SetString(literal, 10); ok {
+			return integer, nil
+		} else if number, ok := new(big.Rat).SetString(literal); ok {
+			return number, nil
+		}
+		return nil, e.NewError("invalid literal ", token.String())
+	}
+
+	if variable, ok := e.variables[token.String()]; ok {
+		return variable, nil
+	}
+
+	if constant, ok := e.Constants[token.String()]; ok {
+		return constant.Value, nil
+	}
+
+	if concept, ok := e.Concepts[token.String()]; ok && e.Peek().Is("(") {
+		e.Scan()
+
+		var arguments []value
+		if !e.ScanIf(')') {
+			for {
+				argument, err := e.expression()
+				if err != nil {
+					return nil, err
+				}
+				arguments = append(arguments, argument)
+
+				if e.ScanIf(')') {
+					break
+				}
+				if !e.ScanIf(',') {
+					return nil, e.Expecting(',')
+				}
+			}
+		}
+
+		return e.call(concept, arguments)
+	}
+
+	//Empty collections, list.integer() and array[n].integer()
+	if T := e.Type(token); compiler.Defined(T) {
+		T, size, err := e.specify(T)
+		if err != nil {
+			return nil, err
+		}
+		if !e.ScanIf('(') || !e.ScanIf(')') {
+			return nil, e.NewError("only empty values of ", T.String(e.Compiler), " are known at compile time, ", T.String(e.Compiler), "()")
+		}
+		result, err := zero(e.Compiler, T)
+		if err != nil {
+			return nil, err
+		}
+
+		//Sized lists start with size empty values, list[n].integer()
+		if list, ok := result.(*collection); ok && size != nil {
+			for i := int64(0); i < size.Int64(); i++ {
+				empty, _ := zero(e.Compiler, T.(compiler.Collection).Subtype())
+				list.values = append(list.values, empty)
+			}
+		}
+		return result, nil
+	}
+
+	return nil, e.NewError(token.String(), " is not known at compile time")
+}
+
+//specify scans the sizes and subtype of a type, the sizes are evaluated so that they can depend on the arguments of a concept.
+//The size of a list is returned separately, as lists are not sized by their type.
+func (e *evaluator) specify(T compiler.Type) (compiler.Type, *big.Int, error) {
+	var sizes []compiler.Expression
+	var length *big.Int
+	if e.ScanIf('[') {
+		for {
+			size, err := e.expression()
+			if err != nil {
+				return nil, nil, err
+			}
+			integer, ok := size.(*big.Int)
+			if !ok || integer.Sign() < 0 || !integer.IsInt64() {
+				return nil, nil, e.NewError("sizes must be positive integers, not ", kind(e.Compiler, size))
+			}
+			sizes = append(sizes, literal(e.Compiler, size))
+			length = integer
+
+			if e.ScanIf(']') {
+				break
+			}
+			if !e.ScanIf(',') {
+				return nil, nil, e.Expecting(']')
+			}
+		}
+	}
+
+	var subtype compiler.Type
+	if e.ScanIf('.') {
+		subtype = e.Type(e.Scan())
+		if !compiler.Defined(subtype) {
+			return nil, nil, e.NewError(e.Token().String() + " is not a type!")
+		}
+		var err error
+		if subtype, _, err = e.specify(subtype); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	collection, ok := T.(compiler.Collection)
+	if !ok {
+		if len(sizes) > 0 || subtype != nil {
+			return nil, nil, e.NewError(T.Name()[compiler.English] + " is not a collection type!")
+		}
+		return T, nil, nil
+	}
+
+	if _, ok := T.(types.List); ok {
+		return collection.With(e.Compiler, subtype), length, nil
+	}
+
+	T, err := collection.Specify(e.Compiler, sizes...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return T.(compiler.Collection).With(e.Compiler, subtype), nil, nil
+}
+
+//sequence scans the elements of a sequence literal, [1, 2, 3]
+func (e *evaluator) sequence() (value, error) {
+	var result = new(collection)
+
+	for {
+		element, err := e.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		var T = literal(e.Compiler, element).Type
+		if result.Type == nil {
+			result.Type = compiler.Sequence{}.With(e.Compiler, T)
+		} else if !T.Equals(result.Type.(compiler.Collection).Subtype()) {
+			return nil, e.NewError("elements in a sequence must share the same type")
+		}
+		result.values = append(result.values, element)
+
+		if e.ScanIf(']') {
+			return result, nil
+		}
+		if !e.ScanIf(',') {
+			return nil, e.Expecting(',')
+		}
+	}
+}
+
+//conditional scans a conditional expression, if n > 0: n | -n
+func (e *evaluator) conditional() (value, error) {
+	condition, err := e.expression()
+	if err != nil {
+		return nil, err
+	}
+	taken, ok := condition.(bool)
+	if !ok {
+		return nil, e.NewError("expecting a logical condition, not ", kind(e.Compiler, condition))
+	}
+
+	if !e.ScanIf(':') {
+		return nil, e.Expecting(':')
+	}
+
+	first, err := e.operand()
+	if err != nil {
+		return nil, err
+	}
+	first, err = e.shunt(first, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if !e.ScanIf('|') {
+		return nil, e.NewError("conditional expressions need a value for when the condition is false, if x: a | b")
+	}
+
+	second, err := e.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if taken {
+		return first, nil
+	}
+	return second, nil
+}
+
+//index returns the value at the index of a collection, indices wrap around like they do at runtime.
+func (e *evaluator) index(of, index value) (value, error) {
+	list, ok := of.(*collection)
+	if !ok {
+		return nil, e.NewError("cannot index ", kind(e.Compiler, of), " at compile time")
+	}
+
+	position, err := e.position(list, index)
+	if err != nil {
+		return nil, err
+	}
+	return list.values[position], nil
+}
+
+//position returns the position of the native value at the index.
+func (e *evaluator) position(list *collection, index value) (int, error) {
+	integer, ok := index.(*big.Int)
+	if !ok {
+		return 0, e.NewError("collections take an integer index, not ", kind(e.Compiler, index))
+	}
+	if len(list.values) == 0 {
+		return 0, e.NewError("index of an empty collection")
+	}
+
+	var length = big.NewInt(int64(len(list.values)))
+	return int(new(big.Int).Mod(integer, length).Int64()), nil
+}
+
+//zero returns the empty value of a type.
+func zero(c *compiler.Compiler, T compiler.Type) (value, error) {
+	switch T := T.(type) {
+	case types.Integer:
+		return new(big.Int), nil
+	case types.Number:
+		return new(big.Rat), nil
+	case types.String:
+		return "", nil
+	case types.Logical:
+		return false, nil
+	case types.List:
+		if T.Subtype() == nil {
+			break
+		}
+		if _, err := zero(c, T.Subtype()); err != nil {
+			return nil, err
+		}
+		return &collection{Type: T}, nil
+	case types.Array:
+		if T.Subtype() == nil {
+			break
+		}
+		var array = &collection{Type: T}
+		for i := 0; i < T.Size; i++ {
+			empty, err := zero(c, T.Subtype())
+			if err != nil {
+				return nil, err
+			}
+			array.values = append(array.values, empty)
+		}
+		return array, nil
+	}
+	return nil, c.NewError("values of type ", T.String(c), " are not known at compile time")
+}
+
+//duplicate returns a copy of the value, collections are copied when they are assigned.
+func duplicate(v value) value {
+	if list, ok := v.(*collection); ok {
+		var copied = &collection{Type: list.Type, values: make([]value, len(list.values))}
+		for i, element := range list.values {
+			copied.values[i] = duplicate(element)
+		}
+		return copied
+	}
+	return v
+}
+
+//unquote returns the text of a string literal, with its interpolations evaluated.
+func (e *evaluator) unquote(token compiler.Token) (value, error) {
+	var c = e.Compiler
+
+	if token.Unclosed() {
+		return nil, c.NewError("string is not closed, expecting \" before the end of the line")
+	}
+
+	var literal = token[1 : len(token)-1]
+	var text []byte
+
+	for i := 0; i < len(literal); i++ {
+		switch literal[i] {
+		case '\\':
+			i++
+			if i >= len(literal) {
+				return nil, c.NewError("unfinished escape sequence")
+			}
+			switch literal[i] {
+			case 'n':
+				text = append(text, '\n')
+			case 't':
+				text = append(text, '\t')
+			case 'r':
+				text = append(text, '\r')
+			case '"', '\\', '{', '}':
+				text = append(text, literal[i])
+			case 'u':
+				var end = strings.IndexByte(string(literal[i:]), '}')
+				if i+1 >= len(literal) || literal[i+1] != '{' || end < 0 {
+					return nil, c.NewError("expecting \\u{hexadecimal}")
+				}
+				code, err := strconv.ParseUint(string(literal[i+2:i+end]), 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return nil, c.NewError("invalid unicode escape \\u", string(literal[i+1:i+end+1]))
+				}
+				text = append(text, string(rune(code))...)
+				i += end
+			default:
+				return nil, c.NewError("unknown escape sequence \\", string(literal[i]))
+			}
+		case '{':
+			var end = compiler.Interpolation(literal, i)
+			if end < 0 {
+				return nil, c.NewError("unclosed { inside of string, use \\{ for a literal {")
+			}
+
+			part, err := e.interpolate(literal[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			text = append(text, part...)
+			i = end
+		default:
+			text = append(text, literal[i])
+		}
+	}
+
+	return string(text), nil
+}
+
+//interpolate evaluates the code of an interpolation inside of a string, "{n}"
+func (e *evaluator) interpolate(code []byte) ([]byte, error) {
+	var saved = e.Scanner
+	defer func() {
+		e.Scanner = saved
+	}()
+
+	e.Scanner = scanner.Scanner{Filename: saved.Filename, LineNumber: saved.LineNumber}
+	e.SetReader(bytes.NewReader(code))
+
+	result, err := e.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	switch result := result.(type) {
+	case string:
+		return []byte(result), nil
+	case *big.Int, bool:
+		return []byte(fmt.Sprint(result)), nil
+	}
+	return nil, e.NewError(kind(e.Compiler, result), " cannot be put inside of a string at compile time")
+}
+
+//operate applies an operator to two values that are known at compile time.
+func operate(c *compiler.Compiler, a, b value, symbol string) (value, error) {
+	//Integers are converted to numbers when operated on with numbers.
+	if integer, ok := a.(*big.Int); ok {
+		if _, ok := b.(*big.Rat); ok {
+			a = new(big.Rat).SetInt(integer)
+		}
+	}
+	if integer, ok := b.(*big.Int); ok {
+		if _, ok := a.(*big.Rat); ok {
+			b = new(big.Rat).SetInt(integer)
+		}
+	}
+
+	switch x := a.(type) {
+	case *big.Int:
+		if y, ok := b.(*big.Int); ok {
+			return operateIntegers(c, x, y, symbol)
+		}
+	case *big.Rat:
+		if y, ok := b.(*big.Rat); ok {
+			return operateNumbers(c, x, y, symbol)
+		}
+	case string:
+		if y, ok := b.(string); ok {
+			switch symbol {
+			case "+":
+				return x + y, nil
+			case "=":
+				return x == y, nil
+			case "!":
+				return x != y, nil
+			case "<":
+				return x < y, nil
+			case ">":
+				return x > y, nil
+			}
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch symbol {
+			case "&":
+				return x && y, nil
+			case "|":
+				return x || y, nil
+			case "=":
+				return x == y, nil
+			case "!":
+				return x != y, nil
+			}
+		}
+	}
+
+	return nil, c.NewError("Operator ", symbol, " does not apply to ", kind(c, a), " and ", kind(c, b))
+}
+
+func operateIntegers(c *compiler.Compiler, x, y *big.Int, symbol string) (value, error) {
+	var result = new(big.Int)
+
+	switch symbol {
+	case "+":
+		return result.Add(x, y), nil
+	case "-":
+		return result.Sub(x, y), nil
+	case "*":
+		return result.Mul(x, y), nil
+	case "/", "%":
+		if y.Sign() == 0 {
+			return nil, c.NewError("division by zero")
+		}
+		if symbol == "/" {
+			return result.Div(x, y), nil
+		}
+		return result.Mod(x, y), nil
+	case "^":
+		if y.Sign() < 0 {
+			return nil, c.NewError("negative powers of integers are not known at compile time")
+		}
+		return result.Exp(x, y, nil), nil
+	case "&":
+		return result.And(x, y), nil
+	case "|":
+		return result.Or(x, y), nil
+	case "<<", ">>":
+		if !y.IsUint64() || y.Uint64() > 1<<16 {
+			return nil, c.NewError("shift of ", y, " is out of range")
+		}
+		if symbol == "<<" {
+			return result.Lsh(x, uint(y.Uint64())), nil
+		}
+		return result.Rsh(x, uint(y.Uint64())), nil
+	case "=":
+		return x.Cmp(y) == 0, nil
+	case "!":
+		return x.Cmp(y) != 0, nil
+	case "<":
+		return x.Cmp(y) < 0, nil
+	case ">":
+		return x.Cmp(y) > 0, nil
+	}
+
+	return nil, c.NewError("Operator ", symbol, " does not apply to integer and integer")
+}
+
+func operateNumbers(c *compiler.Compiler, x, y *big.Rat, symbol string) (value, error) {
+	var result = new(big.Rat)
+
+	switch symbol {
+	case "+":
+		return result.Add(x, y), nil
+	case "-":
+		return result.Sub(x, y), nil
+	case "*":
+		return result.Mul(x, y), nil
+	case "/":
+		if y.Sign() == 0 {
+			return nil, c.NewError("division by zero")
+		}
+		return result.Quo(x, y), nil
+	case "=":
+		return x.Cmp(y) == 0, nil
+	case "!":
+		return x.Cmp(y) != 0, nil
+	case "<":
+		return x.Cmp(y) < 0, nil
+	case ">":
+		return x.Cmp(y) > 0, nil
+	}
+
+	return nil, c.NewError("Operator ", symbol, " does not apply to number and number at compile time")
+}
+
+//kind returns the name of the type of a value.
+func kind(c *compiler.Compiler, v value) string {
+	if v == nil {
+		return "nothing"
+	}
+	return literal(c, v).String(c)
+}
+
+//literal returns the literal expression of a value that is known at compile time.
+func literal(c *compiler.Compiler, v value) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Value = v
+
+	switch v := v.(type) {
+	case *big.Int:
+		expression.Type = types.Integer{}
+		if v.IsInt64() {
+			fmt.Fprintf(&expression.Go, `I.NewInteger(%v)`, v)
+		} else {
+			fmt.Fprintf(&expression.Go, `I.NewIntegerFromString(%v)`, strconv.Quote(v.String()))
+		}
+		fmt.Fprintf(&expression.JS, `%v`, v)
+
+	case *big.Rat:
+		expression.Type = types.Number{}
+		fmt.Fprintf(&expression.Go, `I.NewNumber(%v)`, strconv.Quote(v.RatString()))
+		fmt.Fprintf(&expression.JS, `(%v)`, v.RatString())
+
+	case string:
+		expression.Type = types.String{}
+		expression.Go.WriteString(strconv.Quote(v))
+		js, _ := json.Marshal(v)
+		expression.JS.Write(js)
+
+	case bool:
+		expression.Type = types.Logical{}
+		fmt.Fprintf(&expression.Go, `%v`, v)
+		fmt.Fprintf(&expression.JS, `%v`, v)
+
+	case *collection:
+		expression.Type = v.Type
+
+		var elements = make([]string, len(v.values))
+		var scripts = make([]string, len(v.values))
+		for i, element := range v.values {
+			var item = literal(c, element)
+			elements[i] = item.Go.String()
+			scripts[i] = item.JS.String()
+		}
+
+		fmt.Fprintf(&expression.Go, `%v{%v}`, v.Type.Native(c), strings.Join(elements, ", "))
+		fmt.Fprintf(&expression.JS, `[%v]`, strings.Join(scripts, ", "))
+	}
+
+	return
+}
@@ -0,0 +1,550 @@
+package statement
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/scanner"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//signal is the reason that a block of statements stopped, before reaching its end.
+type signal int
+
+const (
+	finished signal = iota
+	returned
+	broke
+	continued
+
+	//otherwise is a | inside of the block of an if statement, the rest of the block runs when the condition is false.
+	otherwise
+)
+
+//Concepts that are evaluated at compile time are limited, so that those that never return are reported.
+const (
+	maximumDepth = 1000
+	maximumSteps = 100000
+)
+
+//recursion is the error of a concept that calls itself too many times, it is not repeated for each of the calls.
+type recursion struct {
+	error
+}
+
+//call evaluates a concept at compile time, the concept must be pure.
+//Statements that have effects, such as print, are not known at compile time.
+func (e *evaluator) call(concept compiler.Concept, arguments []value) (value, error) {
+	var name = concept.Name.String()
+
+	if e.depth >= maximumDepth {
+		return nil, recursion{e.NewError(name, " calls itself too many times to be evaluated at compile time")}
+	}
+	if len(arguments) != len(concept.Arguments) {
+		return nil, e.NewError(name, " takes ", len(concept.Arguments), " arguments, not ", len(arguments))
+	}
+
+	var frame = evaluator{
+		Compiler:  e.Compiler,
+		variables: make(map[string]value),
+		depth:     e.depth + 1,
+		steps:     e.steps,
+	}
+
+	for i, argument := range concept.Arguments {
+		if argument.Variadic {
+			return nil, e.NewError(name, " is variadic, it cannot be evaluated at compile time")
+		}
+		if compiler.Defined(argument.Type) && !literal(e.Compiler, arguments[i]).Equals(argument.Type) {
+			return nil, e.NewError(name, " takes ", argument.Type.String(e.Compiler), " ", argument.Token.String(), ", not ", kind(e.Compiler, arguments[i]))
+		}
+		frame.variables[argument.Token.String()] = duplicate(arguments[i])
+	}
+
+	result, err := frame.run(concept.Cache)
+	if _, ok := err.(recursion); ok && e.depth > 0 {
+		return nil, err
+	}
+	if err != nil {
+		return nil, e.NewError("inside of ", name, ", ", err.Error())
+	}
+	if result == otherwise {
+		return nil, e.NewError("inside of ", name, ", | requires a preceding if statement")
+	}
+	if frame.returned == nil {
+		return nil, e.NewError(name, " does not return a value")
+	}
+
+	return frame.returned, nil
+}
+
+//run runs a cached block of statements.
+func (e *evaluator) run(cache compiler.Cache) (signal, error) {
+	var saved = e.Scanner
+	defer func() {
+		e.Scanner = saved
+	}()
+
+	e.Scanner = scanner.Scanner{
+		Filename:   cache.Filename,
+		LineNumber: cache.LineNumber,
+	}
+	e.SetReader(bytes.NewReader(cache.Bytes()))
+
+	return e.block()
+}
+
+//block runs statements up to the } that closes the block.
+func (e *evaluator) block() (signal, error) {
+	for {
+		var token = e.Scan()
+
+		switch {
+		case token == nil || token.Is("}"):
+			return finished, nil
+		case token.Is("\n") || (len(token) > 2 && token[0] == '/' && token[1] == '/'):
+			continue
+		case token.Is("|"):
+			return otherwise, nil
+		}
+
+		result, err := e.statement(token)
+		if err != nil || result != finished {
+			return result, err
+		}
+	}
+}
+
+//statement runs the statement that starts with the token.
+func (e *evaluator) statement(token compiler.Token) (signal, error) {
+	*e.steps++
+	if *e.steps > maximumSteps {
+		return finished, e.NewError("compile-time evaluation is taking too long, is there a loop that never ends?")
+	}
+
+	switch token.String() {
+	case "return":
+		if e.Peek().Is("\n") || e.Peek().Is("}") {
+			return finished, e.NewError("concepts that are evaluated at compile time must return a value")
+		}
+		result, err := e.expression()
+		if err != nil {
+			return finished, err
+		}
+		e.returned = result
+		return returned, nil
+
+	case "break", "continue":
+		e.label = ""
+		if !e.Peek().Is("\n") && !e.Peek().Is("}") {
+			e.label = e.Scan().String()
+		}
+		if token.Is("break") {
+			return broke, nil
+		}
+		return continued, nil
+
+	case "if":
+		condition, err := e.expression()
+		if err != nil {
+			return finished, err
+		}
+		taken, ok := condition.(bool)
+		if !ok {
+			return finished, e.NewError("expecting a logical condition, not ", kind(e.Compiler, condition))
+		}
+		return e.branch(taken)
+
+	case "for":
+		return e.loop()
+	}
+
+	var name = token.String()
+
+	//Modifications of collections, name[index] $= value and name[+] $= value
+	if e.Peek().Is("[") {
+		list, ok := e.variables[name].(*collection)
+		if !ok {
+			return finished, e.NewError("cannot modify ", name, " at compile time")
+		}
+		if _, ok := list.Type.(compiler.Sequence); ok {
+			return finished, e.NewError("arguments cannot be modified")
+		}
+		e.Scan()
+
+		var appending = e.ScanIf('+')
+
+		var index value
+		if !appending {
+			var err error
+			if index, err = e.expression(); err != nil {
+				return finished, err
+			}
+		}
+
+		if !e.ScanIf(']') {
+			return finished, e.Expecting(']')
+		}
+		if !e.ScanIf('$') || !e.ScanIf('=') {
+			return finished, e.NewError("expecting $=")
+		}
+
+		modification, err := e.expression()
+		if err != nil {
+			return finished, err
+		}
+		if subtype := list.Type.(compiler.Collection).Subtype(); !literal(e.Compiler, modification).Equals(subtype) {
+			return finished, e.NewError("cannot put ", kind(e.Compiler, modification), " inside of ", list.Type.String(e.Compiler))
+		}
+
+		if appending {
+			if _, ok := list.Type.(types.List); !ok {
+				return finished, e.NewError("only lists can be added to")
+			}
+			list.values = append(list.values, duplicate(modification))
+			return finished, nil
+		}
+
+		position, err := e.position(list, index)
+		if err != nil {
+			return finished, err
+		}
+		list.values[position] = duplicate(modification)
+		return finished, nil
+	}
+
+	//Variables, name $= value
+	if e.ScanIf('$') {
+		if !e.ScanIf('=') {
+			return finished, e.NewError("$ must be followed by =")
+		}
+		if _, ok := e.Constants[name]; ok {
+			if _, ok := e.variables[name]; !ok {
+				return finished, e.NewError("cannot assign to " + name + ", it is a constant")
+			}
+		}
+
+		result, err := e.expression()
+		if err != nil {
+			return finished, err
+		}
+
+		if previous, ok := e.variables[name]; ok && !literal(e.Compiler, result).Equals(literal(e.Compiler, previous).Type) {
+			return finished, e.NewError("cannot assign value of type ", kind(e.Compiler, result), " to variable of type ", kind(e.Compiler, previous))
+		}
+
+		e.variables[name] = duplicate(result)
+		return finished, nil
+	}
+
+	if _, ok := e.Concepts[name]; ok {
+		_, err := e.expressionOf(token)
+		return finished, err
+	}
+
+	return finished, e.NewError(name, " cannot be evaluated at compile time")
+}
+
+//branch runs or skips the block of an if statement.
+//Single line ifs can be followed by || condition: and |: lines, blocks can have a | line that separates the block for when the condition is false.
+func (e *evaluator) branch(taken bool) (signal, error) {
+	if e.ScanIf(':') {
+		var result = finished
+		if taken {
+			var err error
+			if result, err = e.statement(e.Scan()); err != nil || result != finished {
+				return result, err
+			}
+		}
+		e.skipLine()
+
+		//Continuation lines.
+		for e.ScanIf('\n') {
+		}
+		if !e.ScanIf('|') {
+			return result, nil
+		}
+
+		if e.ScanIf('|') {
+			condition, err := e.expression()
+			if err != nil {
+				return finished, err
+			}
+			next, ok := condition.(bool)
+			if !ok {
+				return finished, e.NewError("expecting a logical condition, not ", kind(e.Compiler, condition))
+			}
+			otherwise, err := e.branch(!taken && next)
+			if taken {
+				return result, err
+			}
+			return otherwise, err
+		}
+
+		otherwise, err := e.branch(!taken)
+		if taken {
+			return result, err
+		}
+		return otherwise, err
+	}
+
+	for {
+		if taken {
+			result, err := e.block()
+			if result == otherwise {
+				e.skip()
+				return finished, err
+			}
+			return result, err
+		}
+
+		if !e.skip() {
+			return finished, nil
+		}
+		taken = true
+	}
+}
+
+//skipLine skips the rest of the line without running it.
+func (e *evaluator) skipLine() {
+	for next := e.Peek(); next != nil && !next.Is("\n"); next = e.Peek() {
+		e.Scan()
+	}
+}
+
+//skip skips statements without running them, up to the } that closes the block or up to the | line of the block.
+//It returns true when it stopped at the | line.
+func (e *evaluator) skip() bool {
+	var depth int
+	var start = true
+
+	for {
+		var token = e.Scan()
+		if token == nil {
+			return false
+		}
+
+		switch token.String() {
+		case "\n":
+			start = true
+			continue
+		case "|":
+			if start && depth == 0 && e.Peek().Is("\n") {
+				return true
+			}
+
+			//Continuations of single line ifs, || condition: and |:
+			if start && e.ScanIf('|') {
+				depth++
+			} else if start {
+				e.ScanIf(':')
+			}
+		case "for", "if", "match", "defer", "{":
+			depth++
+		case ":":
+			depth--
+		case "}":
+			if depth == 0 {
+				return false
+			}
+			depth--
+		}
+		start = false
+	}
+}
+
+//loop runs a for loop, the header is worked out again for each loop of a while loop, for n > 0
+func (e *evaluator) loop() (signal, error) {
+	var header []byte
+	var depth int
+	for next := e.Peek(); next != nil && !next.Is("\n") && !(next.Is(":") && depth == 0); next = e.Peek() {
+		switch {
+		case next.Is("if"):
+			depth++
+		case next.Is(":"):
+			depth--
+		}
+		header = append(append(header, e.Scan()...), ' ')
+	}
+
+	var body = e.CacheBlock()
+
+	var saved = e.Scanner
+	var restore = func() { e.Scanner = saved }
+
+	e.Scanner = scanner.Scanner{Filename: body.Filename, LineNumber: body.LineNumber}
+	e.SetReader(bytes.NewReader(header))
+
+	var name compiler.Token
+	var values []value
+	var while bool
+
+	//Numeric loops count from, to, in steps of step.
+	var from, to, step *big.Int
+
+	switch first := e.Scan(); {
+	case first == nil:
+		while = true
+
+	case e.Peek().Is("in") && !e.integral(first):
+		e.Scan()
+		name = first
+		over, err := e.expression()
+		if err != nil {
+			restore()
+			return finished, err
+		}
+		list, ok := over.(*collection)
+		if !ok {
+			restore()
+			return finished, e.NewError("cannot loop over ", kind(e.Compiler, over), " at compile time")
+		}
+		values = duplicate(list).(*collection).values
+
+	default:
+		result, err := e.expressionOf(first)
+		if err != nil {
+			restore()
+			return finished, err
+		}
+		from, step = big.NewInt(1), big.NewInt(1)
+
+		switch result := result.(type) {
+		case bool:
+			while = true
+		case *big.Int:
+			to = result
+
+			//for a to b and for step in b
+			if e.Peek().Is("to") || e.Peek().Is("in") {
+				var word = e.Scan()
+				limit, err := e.expression()
+				if err != nil {
+					restore()
+					return finished, err
+				}
+				integer, ok := limit.(*big.Int)
+				if !ok {
+					restore()
+					return finished, e.NewError("expecting an integer, not ", kind(e.Compiler, limit))
+				}
+
+				to = integer
+				if word.Is("to") {
+					from = result
+					if from.Cmp(to) > 0 {
+						step = big.NewInt(-1)
+					}
+				} else {
+					step = result
+				}
+			}
+		case *collection:
+			to = big.NewInt(int64(len(result.values)))
+		default:
+			restore()
+			return finished, e.NewError("cannot loop over ", kind(e.Compiler, result), " at compile time")
+		}
+	}
+	restore()
+
+	//The loop's variables are only defined inside of the loop.
+	var outside = make(map[string]value)
+	for _, variable := range []string{"i", name.String()} {
+		if previous, ok := e.variables[variable]; ok {
+			outside[variable] = previous
+		}
+	}
+	defer func() {
+		delete(e.variables, "i")
+		delete(e.variables, name.String())
+		for variable, previous := range outside {
+			e.variables[variable] = previous
+		}
+	}()
+
+	//condition works out the header of a while loop.
+	var condition = func() (bool, error) {
+		if len(header) == 0 {
+			return true, nil
+		}
+
+		e.Scanner = scanner.Scanner{Filename: body.Filename, LineNumber: body.LineNumber}
+		e.SetReader(bytes.NewReader(header))
+		defer restore()
+
+		result, err := e.expression()
+		if err != nil {
+			return false, err
+		}
+		logical, ok := result.(bool)
+		if !ok {
+			return false, e.NewError("expecting a logical condition, not ", kind(e.Compiler, result))
+		}
+		return logical, nil
+	}
+
+	for i := 0; ; i++ {
+		*e.steps++
+		if *e.steps > maximumSteps {
+			return finished, e.NewError("compile-time evaluation is taking too long, is there a loop that never ends?")
+		}
+
+		switch {
+		case while:
+			ok, err := condition()
+			if err != nil {
+				return finished, err
+			}
+			if !ok {
+				return finished, nil
+			}
+			if len(header) == 0 {
+				e.variables["i"] = big.NewInt(int64(i + 1))
+			}
+		case to != nil:
+			var position = new(big.Int).Mul(step, big.NewInt(int64(i)))
+			position.Add(position, from)
+			if position.Cmp(to)*step.Sign() > 0 || step.Sign() == 0 && i > 0 {
+				return finished, nil
+			}
+			e.variables["i"] = position
+		default:
+			if i >= len(values) {
+				return finished, nil
+			}
+			e.variables["i"] = big.NewInt(int64(i))
+			e.variables[name.String()] = values[i]
+		}
+
+		result, err := e.run(body)
+		if err != nil {
+			return finished, err
+		}
+
+		switch result {
+		case returned:
+			return returned, nil
+		case otherwise:
+			return finished, e.NewError("| requires a preceding if statement")
+		case broke, continued:
+			if e.label != "" && (name == nil || !name.Is(e.label)) {
+				return result, nil
+			}
+			e.label = ""
+			if result == broke {
+				return finished, nil
+			}
+		}
+	}
+}
+
+//integral returns true if the token is an integer, or the name of a variable that is an integer.
+func (e *evaluator) integral(token compiler.Token) bool {
+	if _, ok := e.variables[token.String()].(*big.Int); ok {
+		return true
+	}
+	_, ok := new(big.Int).SetString(token.String(), 10)
+	return ok
+}
@@ -1,6 +1,9 @@
 package statement
 
 import (
+	"bytes"
+	"fmt"
+
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/types"
 )
@@ -20,15 +23,24 @@ func (For) Name() compiler.String {
 //Compile compiles this statement.
 func (For) Compile(c *compiler.Compiler) error {
 	if c.Peek().Is(":") {
+		var loop = label(c, nil)
+
 		c.Indent()
+		loop.begin(c)
 		c.Go.WriteString("for i := I.NewInteger(1); true; i = i.Add(I.NewInteger(1)) {")
 
 		c.GainScope()
+		loop.enter(c, nil)
 		c.SetVariable(compiler.Token("i"), types.Integer{})
 
 		return c.CompileBlock()
 	}
 
+	if c.Peek().Is("until") {
+		c.Scan()
+		return until(c)
+	}
+
 	var name = c.Scan()
 
 	var numeric bool
@@ -45,7 +57,7 @@ func (For) Compile(c *compiler.Compiler) error {
 	}
 
 	var collection compiler.Collection
-	if !numeric && c.Peek().Is(":") {
+	if !numeric && !c.Peek().Is("in") {
 		var ok bool
 
 		expression, err := c.Expression(name)
@@ -58,6 +70,10 @@ func (For) Compile(c *compiler.Compiler) error {
 			return err
 		}
 
+		if expression.Equals(types.Logical{}) {
+			return while(c, expression)
+		}
+
 		collection, ok = expression.Type.(compiler.Collection)
 		if !ok {
 			return c.NewError("short for loops must be of collection type ,not", expression.String(c))
@@ -86,6 +102,10 @@ func (For) Compile(c *compiler.Compiler) error {
 				return err
 			}
 
+			if expression.Equals(types.Logical{}) {
+				return while(c, expression)
+			}
+
 			if c.Peek().Is("in") {
 				c.Scan()
 
@@ -95,7 +115,9 @@ func (For) Compile(c *compiler.Compiler) error {
 				}
 
 				if target.Equals(types.Integer{}) {
+					var loop = label(c, nil)
 
+					loop.begin(c)
 					c.Go.WriteString("for i, in, to := I.SetupStep(")
 					c.Go.Write(target.Go.Bytes())
 					c.Go.WriteString(",")
@@ -103,6 +125,7 @@ func (For) Compile(c *compiler.Compiler) error {
 					c.Go.WriteString("); i.CompareStep(to, in); i = i.Add(in) {")
 
 					c.GainScope()
+					loop.enter(c, nil)
 					c.SetVariable(compiler.Token("i"), types.Integer{})
 					return c.CompileBlock()
 				}
@@ -115,6 +138,9 @@ func (For) Compile(c *compiler.Compiler) error {
 				if err != nil {
 					return err
 				}
+				var loop = label(c, nil)
+
+				loop.begin(c)
 				c.Go.WriteString("for i, to := I.SetupTo(")
 				c.Go.Write(expression.Go.Bytes())
 				c.Go.WriteString(",")
@@ -122,16 +148,21 @@ func (For) Compile(c *compiler.Compiler) error {
 				c.Go.WriteString("); i.Compare(to) != 0; i = i.To(to) {")
 
 				c.GainScope()
+				loop.enter(c, nil)
 				c.SetVariable(compiler.Token("i"), types.Integer{})
 				return c.CompileBlock()
 			}
 		}
 
+		var loop = label(c, nil)
+
+		loop.begin(c)
 		c.Go.WriteString("for ")
 		c.Go.WriteString("i := I.NewInteger(1); i.Compare(")
 		c.Go.Write(expression.Go.Bytes())
 		c.Go.WriteString(") <= 0; i = i.Add(I.NewInteger(1)) {")
 		c.GainScope()
+		loop.enter(c, nil)
 		c.SetVariable(compiler.Token("i"), types.Integer{})
 
 		return c.CompileBlock()
@@ -141,6 +172,10 @@ func (For) Compile(c *compiler.Compiler) error {
 		return c.NewError("expecting 'in'")
 	}
 
+	if err := c.Unshadowed(name, "a loop variable"); err != nil {
+		return err
+	}
+
 	expression, err := c.ScanExpression()
 	if err != nil {
 		return err
@@ -150,17 +185,289 @@ func (For) Compile(c *compiler.Compiler) error {
 		return c.NewError("unimplemented for loop for " + expression.String(c))
 	}
 
+	//Tables are iterated by key, in order.
+	if table, ok := expression.Type.(types.Table); ok {
+		var keys = table.Keys(c, expression)
+
+		var loop = label(c, name)
+
+		c.Indent()
+		loop.begin(c)
+		c.Go.WriteString("for _, ")
+		c.Go.Write(name)
+		c.Go.WriteString(" := range ")
+		c.Go.Write(keys.Go.Bytes())
+		c.Go.WriteString(" {")
+
+		c.JS.WriteString("for (const ")
+		c.JS.Write(name)
+		c.JS.WriteString(" of ")
+		c.JS.Write(keys.JS.Bytes())
+		c.JS.WriteString(") {")
+
+		c.GainScope()
+		loop.enter(c, name)
+		c.SetVariable(name, table.Key())
+
+		return c.CompileBlock()
+	}
+
+	if c.Peek().Is("parallel") {
+		c.Scan()
+		return parallel(c, name, expression)
+	}
+
+	//Channels are iterated until they are closed.
+	if channel, ok := expression.Type.(types.Channel); ok {
+		var loop = label(c, name)
+
+		c.Indent()
+		loop.begin(c)
+		fmt.Fprintf(&c.Go, "for %v := range %v { _ = %v\n", name, expression.Go, name)
+
+		c.GainScope()
+		loop.enter(c, name)
+		c.SetVariable(name, channel.Subtype())
+
+		return c.CompileBlock()
+	}
+
+	var loop = label(c, name)
+
 	c.Indent()
+	loop.begin(c)
 	c.Go.WriteString("for ")
-	c.Go.WriteString("i,")
-	c.Go.Write(name)
-	c.Go.WriteString(":= range ")
-	c.Go.Write(expression.Go.Bytes())
-	c.Go.WriteString("{")
+
+	//The position is an integer, unless the value is named i, neither need to be used.
+	if name.Is("i") {
+		fmt.Fprintf(&c.Go, "_, i := range %v { _ = i\n", expression.Go)
+	} else {
+		fmt.Fprintf(&c.Go, "i_, %v := range %v { var i = I.NewInteger(int64(i_)); _, _ = i, %v\n", name, expression.Go, name)
+	}
 
 	c.GainScope()
+	loop.enter(c, name)
+	if !name.Is("i") {
+		c.SetVariable(compiler.Token("i"), types.Integer{})
+	}
 	c.SetVariable(name, expression.Type.(compiler.Collection).Subtype())
-	c.SetVariable(compiler.Token("i"), types.Integer{})
 
 	return c.CompileBlock()
 }
+
+//while compiles a loop that runs for as long as the condition is true, for n > 0
+func while(c *compiler.Compiler, condition compiler.Expression) error {
+	var loop = label(c, nil)
+
+	c.Indent()
+	loop.begin(c)
+	fmt.Fprintf(&c.Go, "for %v {", condition.Go)
+
+	c.GainScope()
+	loop.enter(c, nil)
+
+	return c.CompileBlock()
+}
+
+//until compiles a loop that runs until the condition is true, the condition is checked after the body, so the body runs at least once, for until n > 9
+func until(c *compiler.Compiler) error {
+	condition, err := c.ScanExpression()
+	if err != nil {
+		return err
+	}
+	if !condition.Equals(types.Logical{}) {
+		condition, err = c.Cast(condition, types.Logical{})
+		if err != nil {
+			return err
+		}
+	}
+
+	var loop = label(c, nil)
+
+	c.Indent()
+	loop.begin(c)
+	fmt.Fprintf(&c.Go, "for first_ := true; first_ || !(%v); first_ = false {", condition.Go)
+
+	c.GainScope()
+	loop.enter(c, nil)
+
+	return c.CompileBlock()
+}
+
+//reduction is a variable that is combined across the workers of a parallel loop.
+type reduction struct {
+	name     compiler.Token
+	variable compiler.Expression
+	operator string
+}
+
+//parallel compiles a loop that splits the collection across workers, for x in numbers parallel total +, lowest min, highest max
+//Each worker has its own copy of the reductions, which are combined when the worker finishes, and its own errors, which are passed on once all of the workers have finished.
+//Other variables from outside of the loop cannot be modified.
+func parallel(c *compiler.Compiler, name compiler.Token, collection compiler.Expression) error {
+	switch collection.Type.(type) {
+	case types.List, types.Array, compiler.Sequence:
+	default:
+		return c.NewError("parallel loops are over lists and arrays, not ", collection.String(c))
+	}
+
+	var reductions []reduction
+	for !c.Peek().Is("\n") && !c.Peek().Is(":") {
+		var variable = c.Scan()
+
+		var T = c.GetVariable(variable)
+		if !compiler.Defined(T) {
+			return c.Undefined(variable)
+		}
+
+		var operator = c.Scan().String()
+		switch operator {
+		case "+", "min", "max":
+		default:
+			return c.NewError("expecting +, min or max after ", variable.String(), " not ", operator)
+		}
+
+		var expression = c.NewExpression()
+		expression.Type = T
+		expression.Go.Write(variable)
+
+		reductions = append(reductions, reduction{variable, expression, operator})
+
+		if !c.ScanIf(',') {
+			break
+		}
+	}
+
+	c.Import("runtime")
+	c.Import("sync")
+
+	var loop = label(c, name)
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, "{ var items_ = %v; var group_ sync.WaitGroup; var mutex_ sync.Mutex; _ = mutex_; var contexts_ []I.Context; ", collection.Go)
+	//The workers start from the initial values, they are read once before the workers start because the workers write to the variables.
+	var parameters, initials bytes.Buffer
+	for _, reduction := range reductions {
+		var initial = reduction.variable.Go.String()
+		if reduction.operator == "+" {
+			initial = reduction.variable.Type.Zero(c).Go.String()
+		}
+		fmt.Fprintf(&c.Go, "var %v_ = &%v; var %v_initial_ = %v; ", reduction.name, reduction.name, reduction.name, initial)
+		fmt.Fprintf(&parameters, ", %v %s", reduction.name, reduction.variable.Type.Native(c))
+		fmt.Fprintf(&initials, ", %v_initial_", reduction.name)
+	}
+	c.Go.WriteString("var size_ = (len(items_) + runtime.NumCPU() - 1) / runtime.NumCPU()\n")
+
+	c.Indent()
+	c.Go.WriteString("for start_ := 0; start_ < len(items_); start_ += size_ { var end_ = start_ + size_; if end_ > len(items_) { end_ = len(items_) }\n")
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, "group_.Add(1); contexts_ = append(contexts_, I.NewContext()); go func(start_, end_ int, ctx I.Context%v) { defer group_.Done(); _ = ctx\n", parameters.String())
+
+	c.Indent()
+	loop.begin(c)
+	fmt.Fprintf(&c.Go, "for i_ := start_; i_ < end_; i_++ { var %v = items_[i_]; _ = %v", name, name)
+	if !name.Is("i") {
+		c.Go.WriteString("; var i = I.NewInteger(int64(i_)); _ = i")
+	}
+	c.Go.WriteString("\n")
+
+	//The workers combine their reductions once they have finished their items.
+	var combine bytes.Buffer
+	for _, reduction := range reductions {
+		var shared = c.NewExpression()
+		shared.Type = reduction.variable.Type
+		fmt.Fprintf(&shared.Go, "(*%v_)", reduction.name)
+
+		var symbol = map[string]string{"+": "+", "min": "<", "max": ">"}[reduction.operator]
+
+		ok, result, err := reduction.variable.Type.Operation(c, reduction.variable, shared, symbol)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return c.NewError("cannot ", reduction.operator, " ", reduction.variable.String(c))
+		}
+
+		if reduction.operator == "+" {
+			fmt.Fprintf(&combine, "*%v_ = %v; ", reduction.name, result.Go)
+		} else {
+			fmt.Fprintf(&combine, "if %v { *%v_ = %v }; ", result.Go, reduction.name, reduction.name)
+		}
+	}
+
+	c.GainScope()
+	loop.enter(c, name)
+	c.SetFlag(compiler.Token("parallel"))
+	for _, reduction := range reductions {
+		c.SetVariable(reduction.name, reduction.variable.Type)
+	}
+	if !name.Is("i") {
+		c.SetVariable(compiler.Token("i"), types.Integer{})
+	}
+	c.SetVariable(name, collection.Type.(compiler.Collection).Subtype())
+
+	c.DeferCleanup(func() {
+		c.Go.WriteString("\n")
+		c.Indent()
+		c.Go.WriteString("}")
+		if combine.Len() > 0 {
+			fmt.Fprintf(&c.Go, "; mutex_.Lock(); %vmutex_.Unlock()", combine.String())
+		}
+		fmt.Fprintf(&c.Go, " }(start_, end_, contexts_[len(contexts_)-1]%v) }; group_.Wait()\n", initials.String())
+
+		//The errors of the workers are passed on in the order of their items.
+		c.Indent()
+		c.Go.WriteString("for _, worker_ := range contexts_ { for _, error_ := range worker_.Errors() { ctx.Throw(error_.Code, error_.Message) } }\n")
+	})
+
+	return c.CompileBlock()
+}
+
+//Loop is the Go label of a loop, loops are named after their variable (for row in matrix) so that break and continue can refer to outer loops.
+type Loop struct {
+	compiler.Nothing
+
+	Label string
+	used  *bool
+
+	//scope is the index of the body of the loop.
+	scope int
+}
+
+//label returns a new Go label for a loop with the given name.
+func label(c *compiler.Compiler, name compiler.Token) Loop {
+	if name == nil {
+		name = compiler.Token("loop")
+	}
+	return Loop{Label: c.Label(name.String()), used: new(bool)}
+}
+
+//begin starts the Go code of the loop, Go does not allow unused labels, so the label is written when the loop ends and only if a break or continue refers to it.
+func (loop Loop) begin(c *compiler.Compiler) {
+	c.FlipBuffer()
+}
+
+//enter marks the current scope as the body of the loop, named loops can be referred to by their name.
+func (loop Loop) enter(c *compiler.Compiler, name compiler.Token) {
+	c.SetFlag(compiler.Token("for"))
+
+	loop.scope = len(c.Scope) - 1
+
+	var scope = c.Scope[loop.scope]
+	scope.Table["loop"] = loop
+	if name != nil {
+		scope.Table["loop_"+name.String()] = loop
+	}
+
+	c.DeferCleanup(func() {
+		var js = append([]byte(nil), c.JS.Bytes()...)
+		var code = c.DumpAndReturnBuffer(nil)
+
+		if *loop.used {
+			c.Go.WriteString(loop.Label + ": ")
+		}
+		c.Go.Write(code)
+		c.JS.Write(js)
+	})
+}
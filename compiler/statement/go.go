@@ -0,0 +1,64 @@
+package statement
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+)
+
+//Go runs a concept at the same time as the rest of the program, go worker(jobs, 1)
+//The concept has its own context, so errors that it throws are not seen by the caller.
+type Go struct{}
+
+var _ = compiler.RegisterStatement(Go{})
+
+//Name returns the name of this statement.
+func (Go) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `go`,
+	}
+}
+
+//Compile compiles this statement.
+func (Go) Compile(c *compiler.Compiler) error {
+	var name = c.Scan()
+
+	concept, ok := c.Concepts[name.String()]
+	if !ok || !c.Peek().Is("(") {
+		return c.NewError("go must be followed by a call to a concept")
+	}
+
+	arguments, err := concept.ScanCall(c)
+	if err != nil {
+		return err
+	}
+
+	//The arguments are worked out before the goroutine starts.
+	var parameters = make([]compiler.Expression, len(arguments))
+	for i, argument := range arguments {
+		parameters[i] = c.NewExpression()
+		parameters[i].Type = argument.Type
+		fmt.Fprintf(&parameters[i].Go, "go_%v", i)
+	}
+
+	call, err := c.CallConcept(concept, compiler.Expression{}, parameters)
+	if err != nil {
+		return err
+	}
+	c.Throws = false
+
+	var goroutines = c.Goroutines()
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, "%v.Add(1); go func(ctx I.Context", goroutines)
+	for i, parameter := range parameters {
+		fmt.Fprintf(&c.Go, ", %v %v", parameter.Go, arguments[i].Type.Native(c))
+	}
+	fmt.Fprintf(&c.Go, ") { defer %v.Done(); %v }(I.NewContext()", goroutines, call.Go)
+	for _, argument := range arguments {
+		fmt.Fprintf(&c.Go, ", %v", argument.Go)
+	}
+	c.Go.WriteString(")")
+
+	return nil
+}
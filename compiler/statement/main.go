@@ -29,5 +29,14 @@ func (Main) Compile(c *compiler.Compiler) error {
 
 	c.SetFlag(compiler.Token("main"))
 
+	//The program finishes when all of the goroutines have.
+	c.DeferCleanup(func() {
+		if c.Concurrent() {
+			c.Go.WriteString("\n")
+			c.Indent()
+			c.Go.WriteString(c.Goroutines() + ".Wait()")
+		}
+	})
+
 	return c.CompileBlock()
 }
@@ -0,0 +1,334 @@
+package statement
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/types"
+)
+
+//Match is a match statement, it runs the first arm with a value that matches, or the | arm when none match.
+//
+//	match x
+//		1, 2: print("small")
+//		3 to 9
+//			print("medium")
+//		}
+//		|: print("large")
+//	}
+type Match struct{}
+
+var _ = compiler.RegisterStatement(Match{})
+
+//Name returns the name of this statement.
+func (Match) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `match`,
+	}
+}
+
+//Compile compiles this statement.
+func (Match) Compile(c *compiler.Compiler) error {
+	subject, err := c.ScanExpression()
+	if err != nil {
+		return err
+	}
+
+	if !c.ScanIf('\n') {
+		return c.NewError("match must be followed by a block of arms")
+	}
+
+	//Types are matched at compile time.
+	if meta, ok := subject.Type.(types.Metatype); ok {
+		return matchType(c, meta.Type)
+	}
+
+	var value = c.NewExpression()
+	value.Type = subject.Type
+	value.Go.WriteString("match_")
+
+	var matched = make(map[string]bool)
+	var arms []matchArm
+	var otherwise bool
+
+	for {
+		if c.ScanIf('\n') {
+			continue
+		}
+
+		if c.ScanIf('}') {
+			break
+		}
+
+		var current matchArm
+
+		if c.Peek().Is("|") {
+			c.Scan()
+			if otherwise {
+				return c.NewError("match already has a | arm")
+			}
+			otherwise = true
+			current.otherwise = true
+		} else {
+			current.conditions, current.tags, err = patterns(c, value, matched)
+			if err != nil {
+				return err
+			}
+		}
+
+		//Arms are compiled on their own, the switch is written once it is known whether every value is a literal.
+		c.FlipBuffer()
+		if err := arm(c, c.CacheBlock()); err != nil {
+			c.DumpBuffer(nil)
+			return err
+		}
+		current.body = c.DumpAndReturnBuffer(nil)
+
+		arms = append(arms, current)
+	}
+
+	if len(arms) == 0 || len(arms) == 1 && otherwise {
+		return c.NewError("match needs at least one arm with a value")
+	}
+
+	//Logicals only have two values, so they can be checked.
+	if subject.Equals(types.Logical{}) && !otherwise && !(matched["true"] && matched["false"]) {
+		return c.NewError("match on a logical must have arms for true and false, or a | arm")
+	}
+
+	var tagged = true
+	for _, arm := range arms {
+		if len(arm.tags) != len(arm.conditions) {
+			tagged = false
+		}
+	}
+
+	c.Indent()
+	switch {
+	case tagged && subject.Equals(types.Integer{}):
+		fmt.Fprintf(&c.Go, "switch match_ := %v; func() int64 { if !I.NewInteger(match_.Int64()).Equals(match_) { return %v }; return match_.Int64() }() {", subject.Go, unmatched(matched))
+	case tagged:
+		fmt.Fprintf(&c.Go, "switch %v {", subject.Go)
+	default:
+		fmt.Fprintf(&c.Go, "switch match_ := %v; {", subject.Go)
+	}
+
+	for _, arm := range arms {
+		c.Go.WriteString("\n")
+		c.Indent()
+		switch {
+		case arm.otherwise:
+			c.Go.WriteString("default: {")
+		case tagged:
+			fmt.Fprintf(&c.Go, "case %v: {", strings.Join(arm.tags, ", "))
+		default:
+			fmt.Fprintf(&c.Go, "case %v: {", strings.Join(arm.conditions, ", "))
+		}
+		c.Go.Write(arm.body)
+	}
+
+	c.Go.WriteString("\n")
+	c.Indent()
+	c.Go.WriteString("}")
+
+	return nil
+}
+
+//matchArm is a compiled arm of a match statement.
+type matchArm struct {
+	//conditions are the Go conditions of the values of the arm.
+	conditions []string
+
+	//tags are the native literals of the values of the arm, they are left out when a value is not a literal.
+	tags []string
+
+	otherwise bool
+	body      []byte
+}
+
+//unmatched returns an integer that is not matched by any arm, integers that do not fit inside of an int64 are switched on as this value.
+func unmatched(matched map[string]bool) int64 {
+	var value int64
+	for matched[strconv.FormatInt(value, 10)] {
+		value++
+	}
+	return value
+}
+
+//patterns scans the comma-separated values of a match arm and returns the Go conditions and native literals for them.
+//Values can be ranges, 1 to 9, to 0 and 10 to
+func patterns(c *compiler.Compiler, subject compiler.Expression, matched map[string]bool) (conditions, tags []string, err error) {
+	for {
+		var condition string
+
+		var lower, upper compiler.Expression
+		var ranged bool
+
+		if c.Peek().Is("to") {
+			ranged = true
+		} else {
+			lower, err = c.ScanExpression()
+			if err != nil {
+				return nil, nil, err
+			}
+			ranged = c.Peek().Is("to")
+		}
+
+		if ranged {
+			c.Scan()
+			if next := c.Peek(); !next.Is(",") && !next.Is(":") && !next.Is("\n") {
+				upper, err = c.ScanExpression()
+				if err != nil {
+					return nil, nil, err
+				}
+			}
+
+			var bounds []string
+			if compiler.Defined(lower.Type) {
+				below, err := operation(c, subject, lower, "<")
+				if err != nil {
+					return nil, nil, err
+				}
+				bounds = append(bounds, "!"+below)
+			}
+			if compiler.Defined(upper.Type) {
+				above, err := operation(c, subject, upper, ">")
+				if err != nil {
+					return nil, nil, err
+				}
+				bounds = append(bounds, "!"+above)
+			}
+			if len(bounds) == 0 {
+				return nil, nil, c.NewError("expecting a value before or after to")
+			}
+			condition = strings.Join(bounds, " && ")
+		} else {
+			condition, err = operation(c, subject, lower, "=")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if native, ok := tag(subject, lower); ok {
+				if matched[native] {
+					return nil, nil, c.NewError("match already has an arm for ", native)
+				}
+				matched[native] = true
+				tags = append(tags, native)
+			} else {
+				matched[lower.Go.String()] = true
+			}
+		}
+
+		conditions = append(conditions, condition)
+
+		if !c.ScanIf(',') {
+			return conditions, tags, nil
+		}
+	}
+}
+
+//tag returns the native literal of a value that is known at compile time, so that it can be switched on.
+func tag(subject, value compiler.Expression) (string, bool) {
+	if !value.Equals(subject.Type) {
+		return "", false
+	}
+	switch v := value.Value.(type) {
+	case *big.Int:
+		if v.IsInt64() {
+			return v.String(), true
+		}
+	case rune:
+		return strconv.QuoteRune(v), true
+	case string:
+		return strconv.Quote(v), true
+	}
+	return "", false
+}
+
+//operation returns the Go code of a logical operation between the subject of a match and a value.
+func operation(c *compiler.Compiler, subject, value compiler.Expression, symbol string) (string, error) {
+	ok, result, err := subject.Type.Operation(c, subject, value, symbol)
+	if err != nil {
+		return "", err
+	}
+	if !ok || !result.Equals(types.Logical{}) {
+		return "", c.NewError("cannot match ", subject.String(c), " against ", value.String(c))
+	}
+	return "(" + result.Go.String() + ")", nil
+}
+
+//matchType compiles the first arm that lists the type, or the | arm, the other arms are not compiled.
+func matchType(c *compiler.Compiler, T compiler.Type) error {
+	var selected, otherwise *compiler.Cache
+
+	for {
+		if c.ScanIf('\n') {
+			continue
+		}
+
+		if c.ScanIf('}') {
+			break
+		}
+
+		var matches bool
+
+		if c.Peek().Is("|") {
+			c.Scan()
+			if otherwise != nil {
+				return c.NewError("match already has a | arm")
+			}
+			var cache = c.CacheBlock()
+			otherwise = &cache
+			continue
+		}
+
+		for {
+			value, err := c.ScanExpression()
+			if err != nil {
+				return err
+			}
+			meta, ok := value.Type.(types.Metatype)
+			if !ok {
+				return c.NewError("expecting a type, not ", value.String(c))
+			}
+			if meta.Type.Equals(T) {
+				matches = true
+			}
+			if !c.ScanIf(',') {
+				break
+			}
+		}
+
+		var cache = c.CacheBlock()
+		if matches && selected == nil {
+			selected = &cache
+		}
+	}
+
+	if selected == nil {
+		selected = otherwise
+	}
+
+	if selected == nil {
+		return nil
+	}
+
+	c.Indent()
+	c.Go.WriteString("{")
+	return arm(c, *selected)
+}
+
+//arm compiles the block of a match arm in a new scope.
+func arm(c *compiler.Compiler, cache compiler.Cache) error {
+	var context = c.NewContext()
+	context.Returns = c.Returns
+	context.Thrown = c.Thrown
+	context.Depth = c.Depth
+	context.Scope = append([]compiler.Scope(nil), c.Scope...)
+	context.GainScope()
+
+	return c.CompileCacheWithContext(cache, context)
+}
@@ -11,11 +11,18 @@ import (
 type Field struct {
 	Type
 	Exported bool
+
+	//Index is the position of the field in the thing's definition.
+	Index int
 }
 
 //Thing is a structured type.
 type Thing struct {
 	Fields map[string]Field
+
+	//Named things have a name and can have methods.
+	Named   Token
+	Methods map[string]Concept
 }
 
 //Name returns the name of this type.
@@ -25,10 +32,169 @@ func (Thing) Name() String {
 	}
 }
 
-func (Thing) String(c *Compiler) string {
+//Order returns the names of this thing's fields in the order that they were defined.
+func (thing Thing) Order() []string {
+	var names = make([]string, len(thing.Fields))
+	for name, field := range thing.Fields {
+		names[field.Index] = name
+	}
+	return names
+}
+
+func (thing Thing) String(c *Compiler) string {
+	if thing.Named != nil {
+		return thing.Named.String()
+	}
 	return Thing{}.Name()[c.Language]
 }
 
+//DefineThing defines a named thing with the fields of the next block.
+func (compiler *Compiler) DefineThing(name Token) error {
+	if !compiler.ScanIf('{') && !compiler.ScanIf('\n') {
+		return compiler.NewError("expecting a block after thing " + name.String())
+	}
+
+	if compiler.Things == nil {
+		compiler.Things = make(map[string]Thing)
+	}
+
+	if _, ok := compiler.Things[name.String()]; ok {
+		return compiler.NewError("thing " + name.String() + " is already defined")
+	}
+
+	var thing = Thing{
+		Fields:  make(map[string]Field),
+		Named:   name,
+		Methods: make(map[string]Concept),
+	}
+
+	compiler.Import(Ilang)
+	compiler.FlipBuffer()
+
+	if err := thing.compile(compiler); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&compiler.Go, "\treturn &%v{", name)
+	for _, name := range thing.Order() {
+		fmt.Fprintf(&compiler.Go, `%v: %v,`, name, name)
+	}
+	fmt.Fprintf(&compiler.Go, "}\n}\n\n")
+
+	var header bytes.Buffer
+	fmt.Fprintf(&header, "type %v struct {\n", name)
+	for _, name := range thing.Order() {
+		fmt.Fprintf(&header, "\t%v %v\n", name, thing.Fields[name].Native(compiler))
+	}
+	fmt.Fprintf(&header, "}\n\nfunc New%v(ctx I.Context) *%v {\n", name, name)
+
+	compiler.DumpBufferHead(header.Bytes())
+
+	compiler.Things[name.String()] = thing
+
+	return nil
+}
+
+//DefineMethod defines a method with the given name on this named thing.
+func (thing Thing) DefineMethod(c *Compiler, name Token) error {
+	if _, ok := thing.Fields[name.String()]; ok {
+		return c.NewError(thing.String(c) + " already has a field named " + name.String())
+	}
+
+	if _, ok := thing.Methods[name.String()]; ok {
+		return c.NewError(thing.String(c) + " already has a method named " + name.String())
+	}
+
+	var concept, err = c.ScanConcept(name)
+	if err != nil {
+		return err
+	}
+
+	concept.Receiver = thing
+	thing.Methods[name.String()] = concept
+
+	return nil
+}
+
+//Statement compiles a method call or field modification on this thing.
+func (thing Thing) Statement(c *Compiler, this Expression) error {
+	var shared = c.Shared(this.Go.Bytes())
+
+	for c.ScanIf('.') {
+		var name = c.Scan()
+
+		if method, ok := thing.Methods[name.String()]; ok {
+			return method.RunMethod(c, this)
+		}
+
+		field, err := thing.Index(c, this, name)
+		if err != nil {
+			return err
+		}
+
+		if next, ok := field.Type.(Thing); ok && c.Peek().Is(".") {
+			thing, this = next, field
+			continue
+		}
+
+		if !c.ScanIf('$') {
+			return c.Expecting('$')
+		}
+		if !c.ScanIf('=') {
+			return c.Expecting('=')
+		}
+
+		if shared {
+			return c.NewError("cannot modify " + this.Go.String() + " inside of a parallel loop")
+		}
+
+		value, err := c.ScanExpression()
+		if err != nil {
+			return err
+		}
+
+		if !value.Equals(field.Type) {
+			var old = value
+			value, err = c.Cast(value, field.Type)
+			if err != nil {
+				return c.NewError("cannot assign value of type " + old.Type.String(c) + " to field of type " + field.Type.String(c))
+			}
+		}
+
+		c.Indent()
+		fmt.Fprintf(&c.Go, `%v = %v`, field.Go, value.Go)
+		return nil
+	}
+
+	return c.Expecting('.')
+}
+
+//compile compiles the body of a thing, collecting fields until the closing brace.
+func (thing Thing) compile(c *Compiler) error {
+	c.GainScope()
+	c.SetFlag(Token("thing"))
+	c.SetVariable(Token("thing"), thing)
+
+	var scope = len(c.Scope)
+	for {
+		if c.Peek().Is("}") && len(c.Scope) == scope {
+			c.Scan()
+			c.LoseScope()
+			return nil
+		}
+
+		//Fields on the same line are seperated by semicolons.
+		if c.ScanIf(';') {
+			continue
+		}
+
+		if err := c.CompileStatement(); err != nil {
+			return err
+		}
+		c.Go.WriteString("\n")
+	}
+}
+
 //Index does nothing.
 func (thing Thing) Index(c *Compiler, this Expression, name Token) (expression Expression, err error) {
 	expression = c.NewExpression()
@@ -47,35 +213,16 @@ func (thing Thing) Expression(c *Compiler) (ok bool, expression Expression, err
 	expression = c.NewExpression()
 
 	if c.Token().Is("{") {
-
-		var context = c.NewContext()
-		//Simple case. A function with an unknown return value.
-		context.GainScope()
 		c.FlipBuffer()
 
-		c.GainScope()
-		c.SetFlag(Token("thing"))
-
 		thing.Fields = make(map[string]Field)
-		c.SetVariable(Token("thing"), thing)
-
-		var scope = len(c.Scope)
-		for {
-			if c.Peek().Is("}") && len(c.Scope) == scope {
-				c.Scan()
-				c.LoseScope()
-				break
-			}
-			err := c.CompileStatement()
-			if err != nil {
-				return true, expression, err
-			}
-			c.Go.WriteString("\n")
+		if err := thing.compile(c); err != nil {
+			return true, expression, err
 		}
 		c.Indent()
 
 		fmt.Fprintf(&c.Go, `return %v{`, thing.Native(c))
-		for name := range thing.Fields {
+		for _, name := range thing.Order() {
 			fmt.Fprintf(&c.Go, `%v: %v,`, name, name)
 		}
 		fmt.Fprintf(&c.Go, `}`)
@@ -104,20 +251,41 @@ func (Thing) Cast(c *Compiler, from Expression, to Type) (expression Expression,
 }
 
 //Equals returns true if the other type is equal to this type.
-func (Thing) Equals(other Type) bool {
-	_, ok := other.(Thing)
+func (thing Thing) Equals(other Type) bool {
+	b, ok := other.(Thing)
+
+	if ok && (thing.Named != nil || b.Named != nil) {
+		return bytes.Equal(thing.Named, b.Named)
+	}
+
+	//Anonymous things are equal when their fields are.
+	if ok && thing.Fields != nil && b.Fields != nil {
+		if len(thing.Fields) != len(b.Fields) {
+			return false
+		}
+		for name, field := range thing.Fields {
+			other, exists := b.Fields[name]
+			if !exists || other.Index != field.Index || !field.Equals(other.Type) {
+				return false
+			}
+		}
+	}
+
 	return ok
 }
 
 //Native returns this type's native token.
 func (thing Thing) Native(c *Compiler) (token Token) {
+	if thing.Named != nil && c.Target == target.Go {
+		return Token("*" + thing.Named.String())
+	}
 	if c.Target == target.Go {
 		var buffer bytes.Buffer
 		buffer.WriteString("struct{")
-		for name, field := range thing.Fields {
+		for _, name := range thing.Order() {
 			buffer.WriteString(name)
 			buffer.WriteString(" ")
-			buffer.Write(field.Native(c))
+			buffer.Write(thing.Fields[name].Native(c))
 			buffer.WriteString(";")
 		}
 		buffer.WriteString("}")
@@ -127,8 +295,15 @@ func (thing Thing) Native(c *Compiler) (token Token) {
 }
 
 //Zero returns this type's zero expression.
-func (Thing) Zero(c *Compiler) (expression Expression) {
+func (thing Thing) Zero(c *Compiler) (expression Expression) {
 	expression = c.NewExpression()
+
+	if thing.Named != nil {
+		expression.Type = thing
+		fmt.Fprintf(&expression.Go, `New%v(ctx)`, thing.Named)
+		return
+	}
+
 	expression.Type = Nothing{}
 
 	expression.Go.WriteString(`struct{}{}`)
@@ -136,8 +311,15 @@ func (Thing) Zero(c *Compiler) (expression Expression) {
 }
 
 //Copy returns a copy of the nothing type.
-func (Thing) Copy(c *Compiler, item Expression) (expression Expression, err error) {
+func (thing Thing) Copy(c *Compiler, item Expression) (expression Expression, err error) {
 	expression = c.NewExpression()
+
+	if thing.Named != nil {
+		expression.Type = thing
+		fmt.Fprintf(&expression.Go, `func(thing %v) *%v { return &thing }(*%v)`, thing.Named, thing.Named, item.Go)
+		return
+	}
+
 	expression.Type = Nothing{}
 
 	expression.Go.WriteString(`struct{}{}`)
@@ -120,6 +120,10 @@ func (compiler *Compiler) Type(name []byte) Type {
 		}
 	}
 
+	if thing, ok := compiler.Things[string(name)]; ok {
+		return thing
+	}
+
 	return nil
 }
 
@@ -12,6 +12,8 @@ import (
 type Array struct {
 	Size    int
 	subtype compiler.Type
+
+	inner *Array //inner dimensions of a multi-dimensional array, waiting for a subtype.
 }
 
 //Length returns the size/length/count of this type.
@@ -148,11 +150,25 @@ func (array Array) Copy(c *compiler.Compiler, item compiler.Expression) (express
 func (array Array) Index(c *compiler.Compiler, this compiler.Expression, indices ...compiler.Expression) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	if len(indices) > 1 {
+		return indexDimensions(c, array, this, indices...)
+	}
+
 	if len(indices) != 1 {
 		return expression, c.NewError("array takes 1 integer offset")
 	}
 	var index = indices[0]
 
+	//Slices of arrays are lists, they are copied because arrays are values.
+	if r, ok := index.Type.(compiler.Range); ok {
+		elements, err := Elements(c, this)
+		if err != nil {
+			return elements, err
+		}
+
+		return slice(c, elements, r, array.Subtype())
+	}
+
 	if !index.Equals(Integer{}) {
 		return expression, c.NewError("array takes 1 integer offset")
 	}
@@ -170,10 +186,22 @@ func (array Array) Index(c *compiler.Compiler, this compiler.Expression, indices
 
 //Modify a value of this type with the specified indicies.
 func (array Array) Modify(c *compiler.Compiler, this compiler.Expression, modification compiler.Expression, indices ...compiler.Expression) error {
+	if len(indices) > 1 {
+		return modifyDimensions(c, array, this, modification, indices...)
+	}
+
 	if len(indices) != 1 {
 		return c.NewError("array takes 1 integer offset")
 	}
 
+	if modification.Equals(Integer{}) && array.Subtype() != nil && array.Subtype().Equals(Byte{}) {
+		var err error
+		modification, err = Integer{}.Cast(c, modification, Byte{})
+		if err != nil {
+			return err
+		}
+	}
+
 	if !modification.Equals(array.Subtype()) {
 		return c.NewError("cannot value of type ", modification.String(c), "to array of type ", array.String(c))
 	}
@@ -195,29 +223,95 @@ func (array Array) Modify(c *compiler.Compiler, this compiler.Expression, modifi
 	return nil
 }
 
-//Specify this type with the provided args.
+//Specify this type with the provided args, array[3, 3] is an array of arrays.
 func (array Array) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
-	if len(args) != 1 {
+	if len(args) == 0 {
 		array.Size = 0
 		return array, nil
 	}
 
 	var argument = args[0]
 
-	var integer = string(argument.Go.Bytes())
-
-	size, err := strconv.Atoi(integer[len("I.NewInteger(") : len(integer)-1])
-	if err != nil {
-		return nil, c.NewError("array takes 1 'constant' integer size argument")
+	size, ok := Integer{}.literal(argument)
+	if !ok || size.Sign() < 0 || !size.IsInt64() {
+		return nil, c.NewError("array takes 'constant' integer size arguments")
 	}
 
-	array.Size = size
+	array.Size = int(size.Int64())
+
+	if len(args) > 1 {
+		inner, err := Array{}.Specify(c, args[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		var dimensions = inner.(Array)
+		array.inner = &dimensions
+	}
 
 	return array, nil
 }
 
 //With should return this type containing the provided subtype.
 func (array Array) With(c *compiler.Compiler, subtype compiler.Type) compiler.Type {
+	if array.inner != nil {
+		subtype = array.inner.With(c, subtype)
+		array.inner = nil
+	}
 	array.subtype = subtype
 	return array
 }
+
+//indexDimensions indexes a multi-dimensional collection, one dimension at a time.
+func indexDimensions(c *compiler.Compiler, collection compiler.Collection, this compiler.Expression, indices ...compiler.Expression) (compiler.Expression, error) {
+	inner, err := collection.Index(c, this, indices[0])
+	if err != nil {
+		return inner, err
+	}
+
+	dimension, ok := inner.Type.(compiler.Collection)
+	if !ok {
+		return inner, c.NewError(collection.String(c), " takes 1 index, not ", strconv.Itoa(len(indices)))
+	}
+
+	//The row is bound once, so that the outer indices are not evaluated again by the inner ones.
+	var row = c.NewExpression()
+	row.Type = dimension
+	row.Go.WriteString("row_")
+
+	result, err := dimension.Index(c, row, indices[1:]...)
+	if err != nil {
+		return result, err
+	}
+
+	var expression = c.NewExpression()
+	expression.Type = result.Type
+	fmt.Fprintf(&expression.Go, "func() %s { var row_ = %s; return %s }()", result.Type.Native(c), inner.Go.Bytes(), result.Go.Bytes())
+
+	return expression, nil
+}
+
+//modifyDimensions modifies a multi-dimensional collection, one dimension at a time.
+func modifyDimensions(c *compiler.Compiler, collection compiler.Collection, this, modification compiler.Expression, indices ...compiler.Expression) error {
+	inner, err := collection.Index(c, this, indices[0])
+	if err != nil {
+		return err
+	}
+
+	dimension, ok := inner.Type.(compiler.Collection)
+	if !ok {
+		return c.NewError(collection.String(c), " takes 1 index, not ", strconv.Itoa(len(indices)))
+	}
+
+	//The row is bound once by reference, so that the modification is made to the row inside of the collection.
+	var row = c.NewExpression()
+	row.Type = dimension
+	row.Go.WriteString("(*row_)")
+
+	fmt.Fprintf(&c.Go, "func(row_ *%s) { ", dimension.Native(c))
+	if err := dimension.Modify(c, row, modification, indices[1:]...); err != nil {
+		return err
+	}
+	fmt.Fprintf(&c.Go, " }(&%s)", inner.Go.Bytes())
+
+	return nil
+}
@@ -1 +1,153 @@
 package types
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/target"
+)
+
+//Byte is an 'i' byte, an unsigned 8 bit value that wraps around on overflow.
+//Lists of bytes (list.byte) are binary data buffers.
+type Byte struct {
+	compiler.Nothing
+}
+
+var _ = compiler.RegisterType(Byte{})
+
+//Name returns the name of this type.
+func (Byte) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `byte`,
+	}
+}
+
+func (Byte) String(c *compiler.Compiler) string {
+	return Byte{}.Name()[c.Language]
+}
+
+//Equals returns true if the other type is equal to this type.
+func (Byte) Equals(other compiler.Type) bool {
+	_, ok := other.(Byte)
+	return ok
+}
+
+//Expression does nothing, bytes are created by casting integer literals, byte(0xFF).
+func (Byte) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return
+}
+
+//Operation operates on bytes, integers are converted to bytes except when they are the shift amount.
+func (Byte) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if a.Type == nil {
+		return
+	}
+
+	switch symbol {
+	case "<<", ">>":
+		if !b.Type.Equals(Integer{}) {
+			return
+		}
+		expression.Type = Byte{}
+		fmt.Fprintf(&expression.Go, `(%v %v uint(%v.Int64()))`, a.Go, symbol, b.Go)
+		return true, expression, nil
+	}
+
+	if b.Type.Equals(Integer{}) {
+		b, err = Integer{}.Cast(c, b, Byte{})
+		if err != nil {
+			return true, expression, err
+		}
+	}
+
+	if !b.Type.Equals(Byte{}) {
+		return
+	}
+
+	switch symbol {
+	case "+", "-", "*", "&", "|":
+		expression.Type = Byte{}
+		fmt.Fprintf(&expression.Go, `(%v %v %v)`, a.Go, symbol, b.Go)
+		return true, expression, nil
+
+	case "=":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v == %v)`, a.Go, b.Go)
+		return true, expression, nil
+
+	case "!":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v != %v)`, a.Go, b.Go)
+		return true, expression, nil
+
+	case ">", "<":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v %v %v)`, a.Go, symbol, b.Go)
+		return true, expression, nil
+	}
+
+	return
+}
+
+//Cast casts bytes to integers, numbers, symbols and logicals.
+func (Byte) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if to.Equals(Integer{}) {
+		expression.Type = Integer{}
+		fmt.Fprintf(&expression.Go, `I.NewInteger(int64(%v))`, from.Go)
+		return expression, nil
+	}
+
+	if to.Equals(Number{}) {
+		expression.Type = Integer{}
+		fmt.Fprintf(&expression.Go, `I.NewInteger(int64(%v))`, from.Go)
+		return Integer{}.Cast(c, expression, to)
+	}
+
+	if to.Equals(Symbol{}) {
+		expression.Type = Symbol{}
+		fmt.Fprintf(&expression.Go, `rune(%v)`, from.Go)
+		return expression, nil
+	}
+
+	if to.Equals(Logical{}) {
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v != 0)`, from.Go)
+		return expression, nil
+	}
+
+	return c.CastingError(from, to)
+}
+
+//Native returns this type's native token.
+func (Byte) Native(c *compiler.Compiler) (token compiler.Token) {
+	if c.Target == target.Go {
+		return compiler.Token("byte")
+	}
+	return
+}
+
+//Zero returns this type's zero expression.
+func (Byte) Zero(c *compiler.Compiler) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = Byte{}
+
+	expression.Go.WriteString(`byte(0)`)
+
+	return
+}
+
+//Copy returns a copy of the byte.
+func (Byte) Copy(c *compiler.Compiler, item compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	expression.Type = Byte{}
+
+	expression.Go.WriteB(item.Go)
+
+	return
+}
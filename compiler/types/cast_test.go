@@ -0,0 +1,51 @@
+package types
+
+import (
+	"go/parser"
+	"testing"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/target"
+)
+
+//TestCast casts the zero value of every registered type to every registered type.
+//Each cast must either return an expression of the requested type or an error that says what cannot be cast.
+func TestCast(t *testing.T) {
+	var c = compiler.New()
+
+	//Collections are tested with integers inside of them, binary data is tested as well.
+	var registered = []compiler.Type{List{}.With(&c, Byte{})}
+	for _, T := range compiler.Types {
+		if collection, ok := T.(compiler.Collection); ok && !compiler.Defined(collection.Subtype()) {
+			T = collection.With(&c, Integer{})
+		}
+		registered = append(registered, T)
+	}
+
+	for _, from := range registered {
+		for _, to := range registered {
+			var c = compiler.New()
+			c.SetTarget(target.Go)
+
+			var value = from.Zero(&c)
+			value.Type = from
+
+			var name = from.String(&c) + " to " + to.String(&c)
+
+			expression, err := c.Cast(value, to)
+			if err != nil {
+				if message, expected := err.(compiler.Error).Message, "cannot cast "+name; message != expected {
+					t.Errorf("casting %v: expected error %q, not %q", name, expected, message)
+				}
+				continue
+			}
+
+			if !expression.Equals(to) {
+				t.Errorf("casting %v: returned %v", name, expression.String(&c))
+			}
+			if _, err := parser.ParseExpr(expression.Go.String()); err != nil {
+				t.Errorf("casting %v: returned invalid Go %q: %v", name, expression.Go.String(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/target"
+)
+
+//Channel is an 'i' channel, it passes values between concepts that are running at the same time.
+//Values are sent with ch[+] $= value and received with pop(ch), channel.integer(10) can hold 10 values before sending waits.
+type Channel struct {
+	subtype compiler.Type
+}
+
+var _ = compiler.RegisterType(Channel{})
+
+var _ = compiler.Collection(Channel{})
+
+//Subtype returns the subtype.
+func (channel Channel) Subtype() compiler.Type {
+	return channel.subtype
+}
+
+//Length returns the number of values that are waiting in the channel.
+func (channel Channel) Length(c *compiler.Compiler, this compiler.Expression) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = Integer{}
+	fmt.Fprintf(&expression.Go, `I.NewInteger(int64(len(%v)))`, this.Go)
+	return expression
+}
+
+//Name returns the name of this type.
+func (Channel) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `channel`,
+	}
+}
+
+func (channel Channel) String(c *compiler.Compiler) string {
+	var name = Channel{}.Name()[c.Language]
+	if channel.subtype != nil {
+		name += "." + channel.subtype.String(c)
+	}
+	return name
+}
+
+//Expression does nothing.
+func (Channel) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return
+}
+
+//Operation does nothing.
+func (Channel) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return
+}
+
+//Cast casts integers to buffered channels, channel.integer(10)
+func (channel Channel) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if buffered, ok := to.(Channel); ok && from.Equals(Integer{}) && buffered.subtype != nil {
+		expression.Type = buffered
+		fmt.Fprintf(&expression.Go, `make(%v, %v.Int64())`, buffered.Native(c), from.Go)
+		return expression, nil
+	}
+
+	return c.CastingError(from, to)
+}
+
+//Equals returns true if the other type is equal to this type.
+func (channel Channel) Equals(other compiler.Type) bool {
+	a, ok := other.(Channel)
+
+	if ok && channel.Subtype() != nil {
+		ok = a.Subtype() != nil && channel.Subtype().Equals(a.Subtype())
+	}
+
+	return ok
+}
+
+//Native returns this type's native token.
+func (channel Channel) Native(c *compiler.Compiler) (token compiler.Token) {
+	if c.Target == target.Go {
+		var subtype = "struct{}"
+		if channel.Subtype() != nil {
+			subtype = channel.Subtype().Native(c).String()
+		}
+		return compiler.Token("chan " + subtype)
+	}
+	return
+}
+
+//Zero returns this type's zero expression, a channel that waits for each value to be received.
+func (channel Channel) Zero(c *compiler.Compiler) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = channel
+
+	fmt.Fprintf(&expression.Go, `make(%v)`, channel.Native(c))
+
+	return
+}
+
+//Copy returns the same channel, channels are shared.
+func (channel Channel) Copy(c *compiler.Compiler, item compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	expression.Type = channel
+
+	expression.Go.WriteB(item.Go)
+
+	return
+}
+
+//Index does nothing, values are received with pop.
+func (channel Channel) Index(c *compiler.Compiler, this compiler.Expression, indices ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	return expression, c.NewError("channel cannot be indexed, receive values with pop")
+}
+
+//Modify sends a value on the channel, ch[+] $= value
+func (channel Channel) Modify(c *compiler.Compiler, this compiler.Expression, modification compiler.Expression, indices ...compiler.Expression) error {
+	if len(indices) != 1 || !indices[0].Equals(Sequencer{}) || !indices[0].Type.(Sequencer).Plus {
+		return c.NewError("values are sent on a channel with [+]")
+	}
+
+	if !modification.Equals(channel.Subtype()) {
+		return c.NewError("cannot send value of type ", modification.String(c), " on channel of type ", channel.String(c))
+	}
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, `%v <- %v`, this.Go, modification.Go)
+	return nil
+}
+
+//Pop receives a value from the channel, it waits until there is one, closed channels give the zero value.
+func (channel Channel) Pop(c *compiler.Compiler, this compiler.Expression) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = channel.Subtype()
+
+	fmt.Fprintf(&expression.Go, `(<-%v)`, this.Go)
+	return
+}
+
+//Close closes the channel, loops over the channel stop once the values that were sent have been received.
+func (channel Channel) Close(c *compiler.Compiler, this compiler.Expression) {
+	c.Indent()
+	fmt.Fprintf(&c.Go, `close(%v)`, this.Go)
+}
+
+//Specify this type with the provided args.
+func (channel Channel) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
+	if len(args) != 0 {
+		return nil, c.NewError("channel does not take any arguments")
+	}
+
+	return channel, nil
+}
+
+//With should return this type containing the provided subtype.
+func (channel Channel) With(c *compiler.Compiler, subtype compiler.Type) compiler.Type {
+	channel.subtype = subtype
+	return channel
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+)
+
+//conditional scans a conditional expression, if n > 0: n | -n
+//Both values are cast to the wider of their types, if n > 0: 1 | 0.5 is a number.
+func conditional(c *compiler.Compiler) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	condition, err := c.ScanExpression()
+	if err != nil {
+		return expression, err
+	}
+	if !condition.Equals(Logical{}) {
+		condition, err = c.Cast(condition, Logical{})
+		if err != nil {
+			return expression, err
+		}
+	}
+
+	if !c.ScanIf(':') {
+		return expression, c.Expecting(':')
+	}
+
+	//The first value stops at the |, logicals need brackets, if x: (a | b) | c
+	first, err := c.Expression(c.Scan())
+	if err != nil {
+		return expression, err
+	}
+	first, err = c.Shunt(first, 1)
+	if err != nil {
+		return expression, err
+	}
+
+	if !c.ScanIf('|') {
+		return expression, c.NewError("conditional expressions need a value for when the condition is false, if x: a | b")
+	}
+
+	second, err := c.ScanExpression()
+	if err != nil {
+		return expression, err
+	}
+
+	first, second, err = unify(c, first, second)
+	if err != nil {
+		return expression, err
+	}
+
+	expression.Type = first.Type
+	fmt.Fprintf(&expression.Go, `func() %v { if %v { return %v }; return %v }()`, first.Type.Native(c), condition.Go, first.Go, second.Go)
+	fmt.Fprintf(&expression.JS, `(%v ? %v : %v)`, condition.JS, first.JS, second.JS)
+
+	return expression, nil
+}
+
+//unify casts two values to the same type, the wider type is tried first.
+func unify(c *compiler.Compiler, a, b compiler.Expression) (compiler.Expression, compiler.Expression, error) {
+	if a.Equals(b.Type) {
+		return a, b, nil
+	}
+
+	var order = []compiler.Type{a.Type, b.Type}
+	if width(b.Type) > width(a.Type) {
+		order[0], order[1] = order[1], order[0]
+	}
+
+	for _, to := range order {
+		x, err := c.Cast(a, to)
+		if err != nil {
+			continue
+		}
+		y, err := c.Cast(b, to)
+		if err != nil {
+			continue
+		}
+		return x, y, nil
+	}
+
+	return a, b, c.NewError("the values of a conditional expression cannot be ", a.String(c), " and ", b.String(c))
+}
+
+//width ranks the numeric types by the values that they can hold, other types are not ranked.
+func width(T compiler.Type) int {
+	switch T.(type) {
+	case Byte:
+		return 1
+	case Integer:
+		return 2
+	case Number:
+		return 3
+	}
+	return 0
+}
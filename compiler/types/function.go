@@ -1,16 +1,20 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
 )
 
-//Function is an 'i' function.
+//Function is an 'i' function, function[integer].integer takes an integer and returns an integer.
+//Functions are values, they are created from concepts with typed arguments or from function literals, which capture variables.
 type Function struct {
-	subtype compiler.Type
+	subtype   compiler.Type
+	arguments []compiler.Type
 
+	//Concept is the concept of a function that still needs to be generated for the types that it is applied to.
 	Concept compiler.Concept
 	compiler.Nothing
 }
@@ -31,30 +35,181 @@ func (function Function) Subtype() compiler.Type {
 	return function.subtype
 }
 
-func (Function) String(c *compiler.Compiler) string {
-	return Function{}.Name()[c.Language]
+func (function Function) String(c *compiler.Compiler) string {
+	var name = Function{}.Name()[c.Language]
+	if len(function.arguments) > 0 {
+		name += "["
+		for i, argument := range function.arguments {
+			if i > 0 {
+				name += ", "
+			}
+			name += argument.String(c)
+		}
+		name += "]"
+	}
+	if compiler.Defined(function.subtype) {
+		name += "." + function.subtype.String(c)
+	}
+	return name
+}
+
+//generic returns true if the function is a concept that has not been generated yet.
+func (function Function) generic() bool {
+	return function.Concept.Name != nil
 }
 
 //Expression checks and returns a function.
 func (function Function) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	if concept, ok := c.Concepts[c.Token().String()]; ok {
-		if !c.Peek().Is("(") && len(concept.Arguments) == 0 {
-			var _, returns, err = concept.Generate(c)
-			if err != nil {
-				return true, expression, err
+	if c.Token().Is(Function{}.Name()[c.Language]) && c.Peek().Is("(") {
+		return true, expression, function.literal(c, &expression)
+	}
+
+	if concept, ok := c.Concepts[c.Token().String()]; ok && !c.Peek().Is("(") {
+		expression.Type = function
+		expression.Go.Write(c.Token())
+
+		//Concepts with untyped arguments are generated when they are applied.
+		var args = make([]compiler.Expression, len(concept.Arguments))
+		for i, argument := range concept.Arguments {
+			if !compiler.Defined(argument.Type) || argument.Variadic {
+				function.Concept = concept
+				expression.Type = function
+				return true, expression, nil
 			}
-			function.subtype = returns
-			expression.Type = function
-			expression.Go.Write(c.Token())
-			return true, expression, nil
+			args[i] = argument.Type.Zero(c)
+			function.arguments = append(function.arguments, argument.Type)
+		}
+
+		var id, returns, err = concept.Generate(c, args...)
+		if err != nil {
+			return true, expression, err
 		}
+		if err := throwing(c, concept, id); err != nil {
+			return true, expression, err
+		}
+		function.subtype = returns
+		expression.Type = function
+		return true, expression, nil
 	}
 
 	return
 }
 
+//literal compiles a function literal, function(integer(x)) followed by a block.
+//Variables in the surrounding scope are captured by the function.
+func (function Function) literal(c *compiler.Compiler, expression *compiler.Expression) error {
+	c.Scan()
+
+	var arguments []compiler.Argument
+	if !c.ScanIf(')') {
+		var err error
+		arguments, err = c.ScanArguments()
+		if err != nil {
+			return err
+		}
+	}
+
+	var returns compiler.Type
+
+	var context = c.NewContext()
+	context.Returns = &returns
+	context.Scope = append([]compiler.Scope(nil), c.Scope...)
+	context.GainScope()
+	context.SetFlag(compiler.Token("function"))
+
+	for _, argument := range arguments {
+		if !compiler.Defined(argument.Type) || argument.Variadic {
+			return c.NewError("function literals take typed arguments, such as integer(", argument.Token.String(), ")")
+		}
+		context.SetVariable(argument.Token, argument.Type)
+		function.arguments = append(function.arguments, argument.Type)
+	}
+
+	var cache = c.CacheBlock()
+
+	c.FlipBuffer()
+	if err := c.CompileCacheWithContext(cache, context); err != nil {
+		c.DumpBuffer(nil)
+		return err
+	}
+	var body = c.DumpAndReturnBuffer(nil)
+
+	if *context.Thrown {
+		return c.NewError("function literals cannot throw errors, calls to function values are not handled")
+	}
+
+	function.subtype = returns
+	expression.Type = function
+
+	expression.Go.WriteString("func(ctx I.Context")
+	for i, argument := range arguments {
+		fmt.Fprintf(&expression.Go, ", %v %v", argument.Token, function.arguments[i].Native(c))
+	}
+	expression.Go.WriteString(")")
+	if compiler.Defined(returns) {
+		fmt.Fprintf(&expression.Go, " %v", returns.Native(c))
+	}
+	expression.Go.WriteString(" {\n")
+	expression.Go.Write(bytes.TrimRight(body, "\n"))
+
+	return nil
+}
+
+//throwing returns an error when the generated concept throws errors, calls to function values are not handled.
+func throwing(c *compiler.Compiler, concept compiler.Concept, id compiler.Token) error {
+	if c.Throwing.Get(id.String()) {
+		return c.NewError(concept.Name.String(), " throws errors, it cannot be used as a function value")
+	}
+	return nil
+}
+
+//parameters returns the native parameter list of this function type.
+func (function Function) parameters(c *compiler.Compiler) string {
+	var parameters = "func(ctx I.Context"
+	for _, argument := range function.arguments {
+		parameters += ", _ " + argument.Native(c).String()
+	}
+	return parameters + ")"
+}
+
+//Apply calls the function with the arguments inside of an expression, generic concepts are generated for the argument types.
+func (function Function) Apply(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if !function.generic() {
+		return function.Call(c, this, args...)
+	}
+
+	if len(args) != len(function.Concept.Arguments) {
+		return expression, c.NewError(function.Concept.Name.String(), " takes ", len(function.Concept.Arguments), " arguments, not ", len(args))
+	}
+
+	for i, argument := range function.Concept.Arguments {
+		if compiler.Defined(argument.Type) && !args[i].Equals(argument.Type) {
+			return expression, c.NewError("type mismatch got type " + args[i].String(c) + " expecting type " + argument.Type.String(c))
+		}
+	}
+
+	id, returns, err := function.Concept.Generate(c, args...)
+	if err != nil {
+		return expression, err
+	}
+	if err := throwing(c, function.Concept, id); err != nil {
+		return expression, err
+	}
+
+	if !compiler.Defined(returns) {
+		return expression, c.NewError(function.Concept.Name.String(), " does not return a value")
+	}
+
+	expression.Type = returns
+	fmt.Fprintf(&expression.Go, `%v(ctx, %v)`, this.Go, compiler.Arguments(args))
+
+	return expression, nil
+}
+
 //Operation does nothing.
 func (Function) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
@@ -62,71 +217,170 @@ func (Function) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol
 	return
 }
 
-//Cast does nothing.
+//Cast casts concepts with untyped arguments to function types, the concept is generated for the argument types of the function type.
 func (Function) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	return c.CastingError(from, to)
+	function, ok := from.Type.(Function)
+	typed, cast := to.(Function)
+	if !ok || !cast || !function.generic() || typed.generic() {
+		return c.CastingError(from, to)
+	}
+
+	var concept = function.Concept
+	var name = concept.Name.String()
+
+	if len(concept.Arguments) != len(typed.arguments) {
+		return expression, c.NewError(name, " takes ", len(concept.Arguments), " arguments, not ", len(typed.arguments))
+	}
+
+	var args = make([]compiler.Expression, len(concept.Arguments))
+	for i, argument := range concept.Arguments {
+		if argument.Variadic {
+			return expression, c.NewError(name, " is variadic, it cannot be ", typed.String(c))
+		}
+		if compiler.Defined(argument.Type) && !argument.Type.Equals(typed.arguments[i]) {
+			return expression, c.NewError(name, " takes ", argument.Type.String(c), " ", argument.Token.String(), ", not ", typed.arguments[i].String(c))
+		}
+		args[i] = typed.arguments[i].Zero(c)
+	}
+
+	id, returns, err := concept.Generate(c, args...)
+	if err != nil {
+		return expression, err
+	}
+	if err := throwing(c, concept, id); err != nil {
+		return expression, err
+	}
+
+	if compiler.Defined(returns) != compiler.Defined(typed.subtype) || compiler.Defined(returns) && !returns.Equals(typed.subtype) {
+		return c.CastingError(from, to)
+	}
+
+	expression.Type = typed
+	expression.Go.WriteB(from.Go)
+
+	return expression, nil
 }
 
 //Equals returns true if the other type is equal to this type.
-func (Function) Equals(other compiler.Type) bool {
-	_, ok := other.(Function)
-	return ok
+//Concepts that have not been generated are only equal to themselves, they are cast to function types.
+func (function Function) Equals(other compiler.Type) bool {
+	a, ok := other.(Function)
+	if !ok || function.generic() != a.generic() {
+		return false
+	}
+
+	if function.generic() {
+		return function.Concept.Name.Is(a.Concept.Name.String())
+	}
+
+	if len(function.arguments) != len(a.arguments) {
+		return false
+	}
+	for i := range function.arguments {
+		if !function.arguments[i].Equals(a.arguments[i]) {
+			return false
+		}
+	}
+
+	if compiler.Defined(function.subtype) != compiler.Defined(a.subtype) {
+		return false
+	}
+	return !compiler.Defined(function.subtype) || function.subtype.Equals(a.subtype)
 }
 
 //Native returns this type's native token.
-func (Function) Native(c *compiler.Compiler) (token compiler.Token) {
+func (function Function) Native(c *compiler.Compiler) (token compiler.Token) {
 	if c.Target == target.Go {
-		return compiler.Token("func(ctx I.Context)")
+		var native = function.parameters(c)
+		if compiler.Defined(function.subtype) {
+			native += " " + function.subtype.Native(c).String()
+		}
+		return compiler.Token(native)
 	}
 	return
 }
 
-//Zero returns this type's zero expression.
-func (Function) Zero(c *compiler.Compiler) (expression compiler.Expression) {
+//Zero returns this type's zero expression, a function that returns the zero value.
+func (function Function) Zero(c *compiler.Compiler) (expression compiler.Expression) {
 	expression = c.NewExpression()
-	expression.Type = Function{}
+	expression.Type = function
 
-	expression.Go.WriteString(`func(ctx I.Context) {}`)
+	expression.Go.Write(function.Native(c))
+	if compiler.Defined(function.subtype) {
+		fmt.Fprintf(&expression.Go, ` { return %v }`, function.subtype.Zero(c).Go)
+	} else {
+		expression.Go.WriteString(` {}`)
+	}
 
 	return
 }
 
-//Copy returns a copy of the nothing type.
-func (Function) Copy(c *compiler.Compiler, item compiler.Expression) (expression compiler.Expression, err error) {
+//Copy returns a copy of the function.
+func (function Function) Copy(c *compiler.Compiler, item compiler.Expression) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
-	expression.Type = Function{}
+	expression.Type = function
 
 	expression.Go.WriteB(item.Go)
 
 	return
 }
 
-//Call calls this function.
-func (function Function) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+//call returns the expression of a call to this function, arguments are cast to the argument types.
+func (function Function) call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	if len(args) != len(function.Concept.Arguments) {
-		return expression, c.NewError("Wrong number of argumetns, expecting ", len(function.Concept.Arguments), "but got ", len(args))
+	if len(args) != len(function.arguments) {
+		return expression, c.NewError("wrong number of arguments, expecting ", len(function.arguments), " but got ", len(args))
 	}
 
-	if !compiler.Defined(function.subtype) {
-		return expression, c.NewError("Cannot call ", this.Type.String(c), " in expression context.")
+	for i, argument := range function.arguments {
+		if !args[i].Equals(argument) {
+			args[i], err = c.Cast(args[i], argument)
+			if err != nil {
+				return expression, c.NewError("type mismatch got type " + args[i].String(c) + " expecting type " + argument.String(c))
+			}
+		}
 	}
 
 	expression.Type = function.subtype
 
-	fmt.Fprintf(&expression.Go, `%v(ctx, %v)`, this.Go, compiler.Arguments(args))
+	fmt.Fprintf(&expression.Go, `%v(ctx`, this.Go)
+	for _, argument := range args {
+		fmt.Fprintf(&expression.Go, `, %v`, argument.Go)
+	}
+	expression.Go.WriteString(`)`)
 
-	return
+	return expression, nil
+}
+
+//Call calls this function.
+func (function Function) Call(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) (expression compiler.Expression, err error) {
+	if function.generic() {
+		return function.Apply(c, this, args...)
+	}
+
+	if !compiler.Defined(function.subtype) {
+		return expression, c.NewError("cannot call ", this.Type.String(c), " in expression context")
+	}
+
+	return function.call(c, this, args...)
 }
 
 //Run runs this function.
-func (Function) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) error {
+func (function Function) Run(c *compiler.Compiler, this compiler.Expression, args ...compiler.Expression) error {
+	if function.generic() {
+		return c.NewError("cannot run ", function.Concept.Name.String(), " as a function value, call it directly")
+	}
+
+	expression, err := function.call(c, this, args...)
+	if err != nil {
+		return err
+	}
+
 	c.Indent()
-	c.Go.WriteB(this.Go)
-	c.Go.WriteString(`(ctx)`)
+	c.Go.WriteB(expression.Go)
 	return nil
 }
 
@@ -149,9 +403,17 @@ func (function Function) Modify(c *compiler.Compiler, this compiler.Expression,
 	return c.NewError("function cannot be modified")
 }
 
-//Specify this type with the provided args.
+//Specify this type with the provided argument types, function[integer, string].
 func (function Function) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
-	return nil, c.NewError("function cannot be specified")
+	function.arguments = []compiler.Type{}
+	for _, argument := range args {
+		meta, ok := argument.Type.(Metatype)
+		if !ok {
+			return nil, c.NewError("function takes argument types, not ", argument.String(c))
+		}
+		function.arguments = append(function.arguments, meta.Type)
+	}
+	return function, nil
 }
 
 //With should return this type containing the provided subtype.
@@ -2,7 +2,9 @@ package types
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
@@ -36,41 +38,72 @@ func (Integer) String(c *compiler.Compiler) string {
 	return Integer{}.Name()[c.Language]
 }
 
-//Expression does nothing.
+//Expression returns integer literals of any size.
+//Literals are decimal unless they have a base prefix (0b1010 0o17 0xFF) and digits can be separated with underscores (1_000_000).
 func (Integer) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	//Binary expression.
-	if i, err := strconv.ParseInt(string(c.Token()), 2, 64); err == nil && len(c.Token()) > 0 && c.Token()[0] == '0' {
-		expression.Go.WriteString("I.NewInteger(")
-		expression.Go.WriteString(strconv.Itoa(int(i)))
-		expression.Go.WriteString(")")
-		return true, expression, nil
+	var token = c.Token().String()
+	if len(token) == 0 || token[0] < '0' || token[0] > '9' {
+		return
 	}
 
-	//Hexadecimal expression.
-	if len(c.Token()) > 2 && c.Token()[0] == '0' && c.Token()[1] == 'x' {
-		expression.Go.WriteString("I.NewInteger(")
-		expression.Go.Write(c.Token())
-		expression.Go.WriteString(")")
-		return true, expression, nil
+	var value = new(big.Int)
+
+	if len(token) > 1 && token[0] == '0' && strings.ContainsRune("bBoOxX", rune(token[1])) {
+		if _, valid := value.SetString(token, 0); !valid {
+			return true, expression, c.NewError("invalid integer literal ", token)
+		}
+	} else {
+		var digits = strings.Split(token, "_")
+		for _, group := range digits {
+			if group == "" {
+				return
+			}
+		}
+		if _, valid := value.SetString(strings.Join(digits, ""), 10); !valid {
+			return
+		}
 	}
 
-	//Integer expression.
-	if _, err := strconv.Atoi(string(c.Token())); err == nil {
-		expression.Go.WriteString("I.NewInteger(")
-		expression.Go.Write(c.Token())
-		expression.Go.WriteString(")")
-		return true, expression, nil
+	expression.Type = Integer{}
+	expression.Value = value
+	if value.IsInt64() {
+		fmt.Fprintf(&expression.Go, `I.NewInteger(%v)`, value)
+	} else {
+		fmt.Fprintf(&expression.Go, `I.NewIntegerFromString(%v)`, strconv.Quote(value.String()))
 	}
 
-	return
+	return true, expression, nil
 }
 
 //Operation does nothing.
 func (Integer) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	//Integers are converted to numbers when operated on with numbers.
+	if a.Type != nil && b.Type.Equals(Number{}) {
+		a, err = Integer{}.Cast(c, a, Number{})
+		if err != nil {
+			return true, expression, err
+		}
+		return Number{}.Operation(c, a, b, symbol)
+	}
+
+	//Integers are converted to bytes when operated on with bytes.
+	if a.Type != nil && b.Type.Equals(Byte{}) {
+		a, err = Integer{}.Cast(c, a, Byte{})
+		if err != nil {
+			return true, expression, err
+		}
+		return Byte{}.Operation(c, a, b, symbol)
+	}
+
+	//Operations on integer literals are known at compile time, so that they can size arrays.
+	if b.Type.Equals(Integer{}) && a.Type != nil {
+		expression.Value = fold(a, b, symbol)
+	}
+
 	switch symbol {
 	case "+":
 		if b.Type.Equals(Integer{}) {
@@ -110,6 +143,9 @@ func (Integer) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol
 			expression.Type = Integer{}
 
 			if a.Type == nil {
+				if value, ok := (Integer{}).literal(b); ok {
+					expression.Value = new(big.Int).Neg(value)
+				}
 				fmt.Fprintf(&expression.Go, `%v.Neg()`, b.Go)
 			} else {
 				fmt.Fprintf(&expression.Go, `%v.Sub(%v)`, a.Go, b.Go)
@@ -140,6 +176,22 @@ func (Integer) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol
 			return true, expression, nil
 		}
 
+	case "&", "|", "<<", ">>":
+		if b.Type.Equals(Integer{}) {
+			expression.Type = Integer{}
+
+			var method = map[string]string{
+				"&":  "And",
+				"|":  "Or",
+				"<<": "Lsh",
+				">>": "Rsh",
+			}[symbol]
+
+			fmt.Fprintf(&expression.Go, `%v.%v(%v)`, a.Go, method, b.Go)
+
+			return true, expression, nil
+		}
+
 	case "=":
 		if b.Type.Equals(Integer{}) {
 			expression.Type = Logical{}
@@ -204,6 +256,24 @@ func (Integer) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.
 		return expression, nil
 	}
 
+	if to.Equals(Number{}) {
+		expression.Type = Number{}
+		expression.Go.Write(from.Go.Bytes())
+		expression.Go.WriteString(".Number()")
+		return expression, nil
+	}
+
+	//Integer literals must fit inside of a byte, other integers wrap around.
+	if to.Equals(Byte{}) {
+		if value, ok := (Integer{}).literal(from); ok && (value.Sign() < 0 || value.Cmp(big.NewInt(255)) > 0) {
+			return expression, c.NewError("integer ", value.String(), " does not fit inside of a byte")
+		}
+
+		expression.Type = Byte{}
+		fmt.Fprintf(&expression.Go, `byte(%v.Int64())`, from.Go)
+		return expression, nil
+	}
+
 	if to.Equals(Logical{}) {
 		expression.Go.WriteString("bool(")
 		expression.Go.Write(from.Go.Bytes())
@@ -214,6 +284,61 @@ func (Integer) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.
 	return c.CastingError(from, to)
 }
 
+//fold returns the value of an operation on two integer literals, or nil when it is not known at compile time.
+//Divisions by zero and very large powers and shifts are left to the runtime.
+func fold(a, b compiler.Expression, symbol string) interface{} {
+	x, ok := (Integer{}).literal(a)
+	if !ok {
+		return nil
+	}
+	y, ok := (Integer{}).literal(b)
+	if !ok {
+		return nil
+	}
+
+	var result = new(big.Int)
+	switch symbol {
+	case "+":
+		return result.Add(x, y)
+	case "-":
+		return result.Sub(x, y)
+	case "*":
+		return result.Mul(x, y)
+	case "/", "%":
+		if y.Sign() == 0 {
+			return nil
+		}
+		if symbol == "/" {
+			return result.Div(x, y)
+		}
+		return result.Mod(x, y)
+	case "^":
+		if y.Sign() < 0 || !y.IsInt64() || int64(x.BitLen())*y.Int64() > 1<<16 {
+			return nil
+		}
+		return result.Exp(x, y, nil)
+	case "&":
+		return result.And(x, y)
+	case "|":
+		return result.Or(x, y)
+	case "<<", ">>":
+		if y.Sign() < 0 || y.Cmp(big.NewInt(1<<16)) > 0 {
+			return nil
+		}
+		if symbol == "<<" {
+			return result.Lsh(x, uint(y.Int64()))
+		}
+		return result.Rsh(x, uint(y.Int64()))
+	}
+	return nil
+}
+
+//literal returns the value of an integer expression that is known at compile time, ok is false when the value is not known.
+func (Integer) literal(expression compiler.Expression) (value *big.Int, ok bool) {
+	value, ok = expression.Value.(*big.Int)
+	return
+}
+
 //Equals returns true if the other type is equal to this type.
 func (Integer) Equals(other compiler.Type) bool {
 	_, ok := other.(Integer)
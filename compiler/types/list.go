@@ -30,6 +30,51 @@ func (list List) Subtype() compiler.Type {
 
 var _ = compiler.RegisterType(List{})
 
+func init() {
+	compiler.SequenceSlice = func(c *compiler.Compiler, this compiler.Expression, r compiler.Range) (compiler.Expression, error) {
+		return slice(c, this, r, this.Type.(compiler.Sequence).Subtype())
+	}
+}
+
+//Elements returns the elements of a list, array or sequence as a list, arrays are copied.
+func Elements(c *compiler.Compiler, collection compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	switch T := collection.Type.(type) {
+	case List:
+		return collection, nil
+	case compiler.Sequence:
+		expression.Type = List{subtype: T.Subtype()}
+		expression.Go.WriteB(collection.Go)
+		return expression, nil
+	case Array:
+		var list = List{subtype: T.Subtype()}
+		expression.Type = list
+		fmt.Fprintf(&expression.Go, `func(array %v) %v { return array[:] }(%v)`, T.Native(c), list.Native(c), collection.Go)
+		return expression, nil
+	}
+
+	return expression, c.NewError("expecting a list, array or sequence, not ", collection.String(c))
+}
+
+//slice slices a native Go slice with the range, returning a list with the given subtype.
+func slice(c *compiler.Compiler, this compiler.Expression, r compiler.Range, subtype compiler.Type) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	for _, end := range []compiler.Expression{r.From, r.To} {
+		if end.Type != nil && !end.Equals(Integer{}) {
+			return expression, c.NewError("range takes integer indices, not ", end.String(c))
+		}
+	}
+
+	var list = List{subtype: subtype}
+
+	expression.Type = list
+	fmt.Fprintf(&expression.Go, `%v(%v)`, r.Slice(c, list.Native(c)), this.Go)
+
+	return expression, nil
+}
+
 //Name returns the name of this type.
 func (List) Name() compiler.String {
 	return compiler.String{
@@ -53,11 +98,29 @@ func (List) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expre
 	return
 }
 
-//Operation does nothing.
-func (List) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
+//Operation concatenates lists with +, the result is a new list.
+func (list List) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	return
+	if a.Type == nil || symbol != "+" {
+		return
+	}
+
+	//Sequences can be concatenated onto lists.
+	if sequence, ok := b.Type.(compiler.Sequence); ok {
+		b.Type = List{subtype: sequence.Subtype()}
+	}
+
+	if !b.Equals(list) {
+		return true, expression, c.NewError("cannot add ", list.String(c), " and ", b.String(c))
+	}
+
+	expression.Type = list
+	fmt.Fprintf(&expression.Go, `func(a, b %v) %v { return append(append(make(%v, 0, len(a)+len(b)), a...), b...) }(%v, %v)`,
+		list.Native(c), list.Native(c), list.Native(c), a.Go, b.Go)
+	fmt.Fprintf(&expression.JS, `%v.concat(%v)`, a.JS, b.JS)
+
+	return true, expression, nil
 }
 
 //Cast does nothing.
@@ -65,14 +128,36 @@ func (list List) Cast(c *compiler.Compiler, from compiler.Expression, to compile
 	expression = c.NewExpression()
 
 	if sequence, ok := from.Type.(compiler.Sequence); ok {
+		//Sequences of integers become binary data.
+		if list.Subtype() != nil && list.Subtype().Equals(Byte{}) && sequence.Subtype().Equals(Integer{}) {
+			fmt.Fprintf(&expression.Go, `func(integers []I.Integer) []byte { var data = make([]byte, len(integers)); for i, integer := range integers { data[i] = byte(integer.Int64()) }; return data }(%v)`, from.Go)
+			expression.Type = list
+			return expression, nil
+		}
+
 		list.subtype = sequence.Subtype()
 
 		expression.Go.WriteB(from.Go)
+		expression.JS.WriteB(from.JS)
 
 		expression.Type = list
 		return expression, nil
 	}
 
+	//Strings and symbols are encoded as UTF-8 binary data.
+	if list.Subtype() != nil && list.Subtype().Equals(Byte{}) {
+		switch from.Type.(type) {
+		case String:
+			expression.Type = list
+			fmt.Fprintf(&expression.Go, `[]byte(%v)`, from.Go)
+			return expression, nil
+		case Symbol:
+			expression.Type = list
+			fmt.Fprintf(&expression.Go, `[]byte(string(%v))`, from.Go)
+			return expression, nil
+		}
+	}
+
 	return c.CastingError(from, to)
 }
 
@@ -99,7 +184,7 @@ func (list List) Native(c *compiler.Compiler) (token compiler.Token) {
 	return
 }
 
-//Zero returns this type's zero expression.
+//Zero returns this type's zero expression, an empty list or a list of size empty values, list[size].integer()
 func (list List) Zero(c *compiler.Compiler) (expression compiler.Expression) {
 	expression = c.NewExpression()
 	expression.Type = list
@@ -109,11 +194,13 @@ func (list List) Zero(c *compiler.Compiler) (expression compiler.Expression) {
 		expression.Go.Write(list.Native(c))
 		expression.Go.WriteString(",int(")
 		expression.Go.WriteB(list.size.Go)
-		expression.Go.WriteString(".Int64())+1)")
+		expression.Go.WriteString(".Int64()))")
+		fmt.Fprintf(&expression.JS, "Array.from({length: %v}, () => %v)", list.size.JS, list.Subtype().Zero(c).JS)
 		return
 	}
 
-	fmt.Fprintf(&expression.Go, "make(%v, 1)", list.Native(c))
+	fmt.Fprintf(&expression.Go, "make(%v, 0)", list.Native(c))
+	expression.JS.WriteString("[]")
 
 	return
 }
@@ -133,6 +220,8 @@ func (list List) Copy(c *compiler.Compiler, item compiler.Expression) (expressio
 	expression.Go.WriteB(item.Go)
 	expression.Go.WriteString(`)`)
 
+	fmt.Fprintf(&expression.JS, `%v.slice()`, item.JS)
+
 	return
 }
 
@@ -140,11 +229,19 @@ func (list List) Copy(c *compiler.Compiler, item compiler.Expression) (expressio
 func (list List) Index(c *compiler.Compiler, this compiler.Expression, indices ...compiler.Expression) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	if len(indices) > 1 {
+		return indexDimensions(c, list, this, indices...)
+	}
+
 	if len(indices) != 1 {
 		return expression, c.NewError("array takes 1 integer index")
 	}
 	var index = indices[0]
 
+	if r, ok := index.Type.(compiler.Range); ok {
+		return slice(c, this, r, list.Subtype())
+	}
+
 	if !index.Equals(Integer{}) {
 		return expression, c.NewError("list takes 1 integer offset")
 	}
@@ -162,10 +259,22 @@ func (list List) Index(c *compiler.Compiler, this compiler.Expression, indices .
 
 //Modify a value of this type with the specified indicies.
 func (list List) Modify(c *compiler.Compiler, this compiler.Expression, modification compiler.Expression, indices ...compiler.Expression) error {
+	if len(indices) > 1 {
+		return modifyDimensions(c, list, this, modification, indices...)
+	}
+
 	if len(indices) != 1 {
 		return c.NewError("array takes 1 integer offset")
 	}
 
+	if modification.Equals(Integer{}) && list.Subtype() != nil && list.Subtype().Equals(Byte{}) {
+		var err error
+		modification, err = Integer{}.Cast(c, modification, Byte{})
+		if err != nil {
+			return err
+		}
+	}
+
 	if !modification.Equals(list.Subtype()) {
 		return c.NewError("cannot value of type ", modification.String(c), "to array of type ", list.String(c))
 	}
@@ -182,6 +291,7 @@ func (list List) Modify(c *compiler.Compiler, this compiler.Expression, modifica
 					modification.Go,
 					this.Go,
 				)
+				fmt.Fprintf(&c.JS, "%v.push(%v)", this.JS, modification.JS)
 				return nil
 			}
 		}
@@ -199,6 +309,69 @@ func (list List) Modify(c *compiler.Compiler, this compiler.Expression, modifica
 	return nil
 }
 
+//position returns the native position of the index inside of a list with the given Go and JS length.
+func (list List) position(c *compiler.Compiler, index compiler.Expression, length, js string) (compiler.Expression, error) {
+	if !index.Equals(Integer{}) {
+		return index, c.NewError("list takes 1 integer offset, not ", index.String(c))
+	}
+
+	var expression = c.NewExpression()
+	expression.Type = Integer{}
+	fmt.Fprintf(&expression.Go, `I.IndexList(%v, %v)`, index.Go, length)
+	fmt.Fprintf(&expression.JS, `(((%v %% %v) + %v) %% %v)`, index.JS, js, js, js)
+	return expression, nil
+}
+
+//Insert inserts the value into the list, so that it can be found at the index.
+func (list List) Insert(c *compiler.Compiler, this, index, value compiler.Expression) error {
+	if !value.Equals(list.Subtype()) {
+		return c.NewError("cannot insert value of type ", value.String(c), " into ", list.String(c))
+	}
+
+	position, err := list.position(c, index, "len(*list_)+1", "("+this.JS.String()+".length+1)")
+	if err != nil {
+		return err
+	}
+
+	//The value is passed in, so that the names inside of the function cannot capture the names that it uses.
+	c.Indent()
+	fmt.Fprintf(&c.Go, `func(list_ *%v, value_ %v) { var i_ = %v; *list_ = append(*list_, %v); copy((*list_)[i_+1:], (*list_)[i_:]); (*list_)[i_] = value_ }(&%v, %v)`,
+		list.Native(c), list.Subtype().Native(c), position.Go, list.Subtype().Zero(c).Go, this.Go, value.Go)
+
+	fmt.Fprintf(&c.JS, `%v.splice(%v, 0, %v)`, this.JS, position.JS, value.JS)
+	return nil
+}
+
+//Pop removes the value at the index from the list and returns it, the last value is removed if no index is provided.
+//Popping from an empty list returns the zero value.
+func (list List) Pop(c *compiler.Compiler, this compiler.Expression, index compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	expression.Type = list.Subtype()
+
+	var position = c.NewExpression()
+	position.Go.WriteString(`len(*list_)-1`)
+	position.JS.WriteString(this.JS.String() + `.length-1`)
+
+	if index.Type != nil {
+		if position, err = list.position(c, index, "len(*list_)", this.JS.String()+".length"); err != nil {
+			return expression, err
+		}
+	}
+
+	fmt.Fprintf(&expression.Go, `func(list_ *%v) %v { if len(*list_) == 0 { return %v }; var i_ = %v; var value_ = (*list_)[i_]; *list_ = append((*list_)[:i_], (*list_)[i_+1:]...); return value_ }(&%v)`,
+		list.Native(c), list.Subtype().Native(c), list.Subtype().Zero(c).Go, position.Go, this.Go)
+	fmt.Fprintf(&expression.JS, `(%v.splice(%v, 1)[0] ?? %v)`, this.JS, position.JS, list.Subtype().Zero(c).JS)
+
+	return expression, nil
+}
+
+//Clear removes all of the values from the list.
+func (list List) Clear(c *compiler.Compiler, this compiler.Expression) {
+	c.Indent()
+	fmt.Fprintf(&c.Go, `%v = %v[:0]`, this.Go, this.Go)
+	fmt.Fprintf(&c.JS, `%v.length = 0`, this.JS)
+}
+
 //Specify this type with the provided args.
 func (list List) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
 	if len(args) != 1 {
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"fmt"
+
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
 )
@@ -49,6 +51,11 @@ func (Logical) Expression(c *compiler.Compiler) (ok bool, expression compiler.Ex
 		return true, expression, nil
 	}
 
+	if c.Token().Is("if") {
+		expression, err = conditional(c)
+		return true, expression, err
+	}
+
 	return
 }
 
@@ -91,6 +98,23 @@ func (Logical) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol
 			expression.Go.WriteB(b.Go)
 			expression.Go.WriteString(`)`)
 
+			return true, expression, nil
+		}
+	case "=", "!":
+		if b.Type.Equals(Logical{}) {
+			expression.Type = Logical{}
+
+			var operator = "=="
+			if symbol == "!" {
+				operator = "!="
+			}
+
+			expression.Go.WriteString(`(`)
+			expression.Go.WriteB(a.Go)
+			expression.Go.WriteString(operator)
+			expression.Go.WriteB(b.Go)
+			expression.Go.WriteString(`)`)
+
 			return true, expression, nil
 		}
 	}
@@ -98,10 +122,25 @@ func (Logical) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol
 	return
 }
 
-//Cast does nothing.
+//Cast casts logicals to integers, numbers and bytes, true is 1 and false is 0.
 func (Logical) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	switch to.(type) {
+	case Integer, Number:
+		expression.Type = Integer{}
+		fmt.Fprintf(&expression.Go, `func(b bool) I.Integer { if b { return I.NewInteger(1) }; return I.NewInteger(0) }(%v)`, from.Go)
+		if to.Equals(Number{}) {
+			return Integer{}.Cast(c, expression, to)
+		}
+		return expression, nil
+
+	case Byte:
+		expression.Type = Byte{}
+		fmt.Fprintf(&expression.Go, `func(b bool) byte { if b { return 1 }; return 0 }(%v)`, from.Go)
+		return expression, nil
+	}
+
 	return c.CastingError(from, to)
 }
 
@@ -1,11 +1,18 @@
 package types
 
 import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
 )
 
 //Number can contain any numeric value.
+//Numbers are exact rationals until an operation can only be approximated (such as a fractional power), then they are floating.
+//They print as decimals, fractions (1/3) or the shortest floating form, so that printed numbers can be read back exactly.
 type Number struct {
 	compiler.Nothing
 }
@@ -29,24 +36,111 @@ func (Number) Equals(other compiler.Type) bool {
 	return ok
 }
 
-//Expression does nothing.
+//Expression returns decimal and scientific literals.
 func (Number) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	var token = c.Token()
+
+	if len(token) == 0 || token[0] < '0' || token[0] > '9' {
+		return
+	}
+
+	//Digit separators, 1_000.5
+	var literal = strings.Replace(token.String(), "_", "", -1)
+
+	if _, ok := new(big.Rat).SetString(literal); ok {
+		expression.Type = Number{}
+		fmt.Fprintf(&expression.Go, `I.NewNumber(%v)`, strconv.Quote(literal))
+		return true, expression, nil
+	}
+
 	return
 }
 
-//Operation does nothing.
+//Operation operates on numbers, integers are converted to numbers.
 func (Number) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	if b.Type.Equals(Integer{}) {
+		b, err = Integer{}.Cast(c, b, Number{})
+		if err != nil {
+			return true, expression, err
+		}
+	}
+
+	if !b.Type.Equals(Number{}) {
+		return
+	}
+
+	switch symbol {
+	case "+", "*", "/", "^", "%":
+		expression.Type = Number{}
+
+		var method = map[string]string{
+			"+": "Add",
+			"*": "Mul",
+			"/": "Div",
+			"^": "Pow",
+			"%": "Mod",
+		}[symbol]
+
+		fmt.Fprintf(&expression.Go, `%v.%v(%v)`, a.Go, method, b.Go)
+
+		return true, expression, nil
+
+	case "-":
+		expression.Type = Number{}
+
+		if a.Type == nil {
+			fmt.Fprintf(&expression.Go, `%v.Neg()`, b.Go)
+		} else {
+			fmt.Fprintf(&expression.Go, `%v.Sub(%v)`, a.Go, b.Go)
+		}
+
+		return true, expression, nil
+
+	case "=":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `%v.Equals(%v)`, a.Go, b.Go)
+		return true, expression, nil
+
+	case "!":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `!%v.Equals(%v)`, a.Go, b.Go)
+		return true, expression, nil
+
+	case ">", "<":
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v.Compare(%v) %v 0)`, a.Go, b.Go, symbol)
+		return true, expression, nil
+	}
+
 	return
 }
 
-//Cast does nothing.
+//Cast casts numbers to integers (rounding towards zero), strings and logicals.
 func (Number) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	if to.Equals(Integer{}) {
+		expression.Type = Integer{}
+		fmt.Fprintf(&expression.Go, `%v.Integer()`, from.Go)
+		return expression, nil
+	}
+
+	if to.Equals(String{}) {
+		expression.Type = String{}
+		fmt.Fprintf(&expression.Go, `%v.String()`, from.Go)
+		return expression, nil
+	}
+
+	if to.Equals(Logical{}) {
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `%v.Bool()`, from.Go)
+		return expression, nil
+	}
+
 	return c.CastingError(from, to)
 }
 
@@ -61,7 +155,7 @@ func (Number) Native(c *compiler.Compiler) (token compiler.Token) {
 //Zero returns this type's zero expression.
 func (Number) Zero(c *compiler.Compiler) (expression compiler.Expression) {
 	expression = c.NewExpression()
-	expression.Type = Integer{}
+	expression.Type = Number{}
 
 	expression.Go.WriteString(`I.Number{}`)
 
@@ -1,6 +1,12 @@
 package types
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
 )
@@ -23,24 +29,176 @@ func (String) String(c *compiler.Compiler) string {
 	return String{}.Name()[c.Language]
 }
 
-//Expression does nothing.
+//Expression returns string literals.
+//Literals can contain escape sequences (\" \\ \n \t \r \{ \} \u{263A}) and {expressions} that are converted to strings like print does.
 func (String) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
-	if c.Token()[0] == '"' {
-		expression.Go.Write(c.Token())
-		expression.JS.Write(c.Token())
-		return true, expression, nil
+	if c.Token()[0] != '"' {
+		return
 	}
 
-	return
+	if c.Token().Unclosed() {
+		return true, expression, c.NewError("string is not closed, expecting \" before the end of the line")
+	}
+
+	var literal = c.Token()
+	literal = literal[1 : len(literal)-1]
+
+	var parts []compiler.Expression
+	var text []byte
+
+	var flush = func() {
+		var part = c.NewExpression()
+		part.Type = String{}
+		part.Value = string(text)
+		part.Go.WriteString(strconv.Quote(string(text)))
+		js, _ := json.Marshal(string(text))
+		part.JS.Write(js)
+		parts = append(parts, part)
+		text = nil
+	}
+
+	for i := 0; i < len(literal); i++ {
+		switch literal[i] {
+		case '\\':
+			i++
+			if i >= len(literal) {
+				return true, expression, c.NewError("unfinished escape sequence")
+			}
+			switch literal[i] {
+			case 'n':
+				text = append(text, '\n')
+			case 't':
+				text = append(text, '\t')
+			case 'r':
+				text = append(text, '\r')
+			case '"', '\\', '{', '}':
+				text = append(text, literal[i])
+			case 'u':
+				var end = bytes.IndexByte(literal[i:], '}')
+				if i+1 >= len(literal) || literal[i+1] != '{' || end < 0 {
+					return true, expression, c.NewError("expecting \\u{hexadecimal}")
+				}
+				code, err := strconv.ParseUint(string(literal[i+2:i+end]), 16, 32)
+				if err != nil || !utf8.ValidRune(rune(code)) {
+					return true, expression, c.NewError("invalid unicode escape \\u", string(literal[i+1:i+end+1]))
+				}
+				text = append(text, string(rune(code))...)
+				i += end
+			default:
+				return true, expression, c.NewError("unknown escape sequence \\", string(literal[i]))
+			}
+
+		//Interpolation.
+		case '{':
+			var end = compiler.Interpolation(literal, i)
+			if end < 0 {
+				return true, expression, c.NewError("unclosed { inside of string, use \\{ for a literal {")
+			}
+
+			if len(text) > 0 {
+				flush()
+			}
+
+			value, err := c.ExpressionOf(literal[i+1 : end])
+			if err != nil {
+				return true, expression, err
+			}
+
+			value, err = Sprint(c, value)
+			if err != nil {
+				return true, expression, err
+			}
+
+			parts = append(parts, value)
+			i = end
+
+		default:
+			text = append(text, literal[i])
+		}
+	}
+
+	if len(text) > 0 || len(parts) == 0 {
+		flush()
+	}
+
+	expression.Type = String{}
+
+	if len(parts) == 1 {
+		return true, parts[0], nil
+	}
+
+	expression.Go.WriteString(`(`)
+	expression.JS.WriteString(`(`)
+	for i, part := range parts {
+		if i > 0 {
+			expression.Go.WriteString(` + `)
+			expression.JS.WriteString(` + `)
+		}
+		expression.Go.WriteB(part.Go)
+		expression.JS.WriteB(part.JS)
+	}
+	expression.Go.WriteString(`)`)
+	expression.JS.WriteString(`)`)
+
+	return true, expression, nil
+}
+
+//printable returns true if values of the type can be printed, types that only exist at compile time cannot.
+func printable(T compiler.Type) bool {
+	switch T.(type) {
+	case nil, compiler.Nothing, Metatype, Undefined, Sequencer, Function, Channel:
+		return false
+	}
+	return true
+}
+
+//Sprint converts a value to a string with the same rules that print uses.
+func Sprint(c *compiler.Compiler, value compiler.Expression) (expression compiler.Expression, err error) {
+	switch value.Type.(type) {
+	case String:
+		return value, nil
+	case Symbol, compiler.Thing:
+		var from = value.Type
+		expression, err = from.Cast(c, value, String{})
+		expression.Type = String{}
+		return expression, err
+	}
+
+	c.Import("fmt")
+
+	expression = c.NewExpression()
+	expression.Type = String{}
+	fmt.Fprintf(&expression.Go, `fmt.Sprint(%v)`, value.Go)
+	fmt.Fprintf(&expression.JS, `String(%v)`, value.JS)
+
+	return expression, nil
 }
 
-//Operation does nothing.
+//Operation concatenates and compares strings, strings are ordered by their symbols.
 func (String) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
 	switch symbol {
+	case "=", "!", "<", ">":
+		if a.Type == nil || !b.Type.Equals(String{}) {
+			return
+		}
+
+		var operator = map[string]string{
+			"=": "==",
+			"!": "!=",
+			"<": "<",
+			">": ">",
+		}[symbol]
+
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v %v %v)`, a.Go, operator, b.Go)
+		fmt.Fprintf(&expression.JS, `(%v %v %v)`, a.JS, operator, b.JS)
+
+		return true, expression, nil
+
 	case "+":
 		if b.Type.Equals(String{}) {
 			expression.Type = String{}
@@ -55,6 +213,11 @@ func (String) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol s
 
 			return true, expression, nil
 		}
+		if b.Type.Equals(Symbol{}) {
+			expression.Type = String{}
+			fmt.Fprintf(&expression.Go, `(%v + string(%v))`, a.Go, b.Go)
+			return true, expression, nil
+		}
 	}
 	return
 }
@@ -81,6 +244,64 @@ func (String) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.T
 		return expression, nil
 	}
 
+	//Empty strings are false.
+	if from.Equals(String{}) && to.Equals(Logical{}) {
+		expression.Type = Logical{}
+		fmt.Fprintf(&expression.Go, `(%v != "")`, from.Go)
+		return expression, nil
+	}
+
+	//Binary data is decoded as UTF-8.
+	if list, ok := from.Type.(List); ok && list.Subtype() != nil && list.Subtype().Equals(Byte{}) && to.Equals(String{}) {
+		expression.Type = String{}
+		fmt.Fprintf(&expression.Go, `string(%v)`, from.Go)
+		return expression, nil
+	}
+
+	//Things are formatted as {name: value, ...}
+	if thing, ok := from.Type.(compiler.Thing); ok && to.Equals(String{}) {
+		c.Import("fmt")
+
+		expression.Type = String{}
+		fmt.Fprintf(&expression.Go, `func(thing %v) string { return fmt.Sprint(`, thing.Native(c))
+		for i, name := range thing.Order() {
+			var label = name + ": "
+			if i == 0 {
+				label = "{" + label
+			} else {
+				label = ", " + label
+			}
+			fmt.Fprintf(&expression.Go, `%v, `, strconv.Quote(label))
+
+			var field = c.NewExpression()
+			field.Type = thing.Fields[name].Type
+			fmt.Fprintf(&field.Go, `thing.%v`, name)
+
+			switch field.Type.(type) {
+			case compiler.Thing:
+				field, err = String{}.Cast(c, field, String{})
+			case Symbol:
+				field, err = Symbol{}.Cast(c, field, String{})
+			}
+			if err != nil {
+				return expression, err
+			}
+
+			fmt.Fprintf(&expression.Go, `%v, `, field.Go)
+		}
+		if len(thing.Fields) == 0 {
+			expression.Go.WriteString(`"{", `)
+		}
+		fmt.Fprintf(&expression.Go, `"}") }(%v)`, from.Go)
+
+		return expression, nil
+	}
+
+	//Any other value is converted like print converts it.
+	if to.Equals(String{}) && printable(from.Type) {
+		return Sprint(c, from)
+	}
+
 	return c.CastingError(from, to)
 }
 
@@ -153,6 +374,21 @@ func (String) Index(c *compiler.Compiler, this compiler.Expression, indices ...c
 
 	var index = indices[0]
 
+	//Strings are sliced by symbol.
+	if r, ok := index.Type.(compiler.Range); ok {
+		var symbols = c.NewExpression()
+		fmt.Fprintf(&symbols.Go, `[]rune(%v)`, this.Go)
+
+		symbols, err = slice(c, symbols, r, Symbol{})
+		if err != nil {
+			return expression, err
+		}
+
+		expression.Type = String{}
+		fmt.Fprintf(&expression.Go, `string(%v)`, symbols.Go)
+		return expression, nil
+	}
+
 	expression.Type = Symbol{}
 	expression.Go.WriteString(`ctx.Strindex(`)
 	expression.Go.WriteB(this.Go)
@@ -170,6 +406,9 @@ func (String) Modify(c *compiler.Compiler, this compiler.Expression, modificatio
 
 //Specify this type with the provided args.
 func (String) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
+	if len(args) == 0 {
+		return String{}, nil
+	}
 	return nil, c.NewError("string doesn't take any arguments")
 }
 
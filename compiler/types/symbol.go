@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/qlova/viking/compiler"
 	"github.com/qlova/viking/compiler/target"
 )
@@ -30,16 +33,65 @@ func (Symbol) Expression(c *compiler.Compiler) (ok bool, expression compiler.Exp
 	if c.Token()[0] == '\'' {
 		expression.Type = Symbol{}
 		expression.Go.Write(c.Token())
+		if value, err := strconv.Unquote(c.Token().String()); err == nil {
+			expression.Value = []rune(value)[0]
+		}
 		return true, expression, nil
 	}
 
 	return
 }
 
-//Operation does nothing.
+//Operation compares symbols, moves symbols along by integers ('a' + 1 is 'b'), subtracts symbols and concatenates symbols with strings.
 func (Symbol) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
 	expression = c.NewExpression()
 
+	if a.Type == nil {
+		return
+	}
+
+	switch b.Type.(type) {
+	case Symbol:
+		switch symbol {
+		case "=", "!", "<", ">":
+			var operator = map[string]string{
+				"=": "==",
+				"!": "!=",
+				"<": "<",
+				">": ">",
+			}[symbol]
+
+			expression.Type = Logical{}
+			fmt.Fprintf(&expression.Go, `(%v %v %v)`, a.Go, operator, b.Go)
+			return true, expression, nil
+
+		case "-":
+			expression.Type = Integer{}
+			fmt.Fprintf(&expression.Go, `I.NewInteger(int64(%v - %v))`, a.Go, b.Go)
+			return true, expression, nil
+
+		case "+":
+			expression.Type = String{}
+			fmt.Fprintf(&expression.Go, `(string(%v) + string(%v))`, a.Go, b.Go)
+			return true, expression, nil
+		}
+
+	case Integer:
+		switch symbol {
+		case "+", "-":
+			expression.Type = Symbol{}
+			fmt.Fprintf(&expression.Go, `(%v %v rune(%v.Int64()))`, a.Go, symbol, b.Go)
+			return true, expression, nil
+		}
+
+	case String:
+		if symbol == "+" {
+			expression.Type = String{}
+			fmt.Fprintf(&expression.Go, `(string(%v) + %v)`, a.Go, b.Go)
+			return true, expression, nil
+		}
+	}
+
 	return
 }
 
@@ -61,6 +113,20 @@ func (Symbol) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.T
 		return expression, nil
 	}
 
+	//Symbol literals must fit inside of a byte, other symbols wrap around.
+	if to.Equals(Byte{}) {
+		expression.Type = Byte{}
+		if value, ok := from.Value.(rune); ok {
+			if value > 255 {
+				return expression, c.NewError("symbol ", string(value), " does not fit inside of a byte")
+			}
+			fmt.Fprintf(&expression.Go, `byte(%v)`, from.Go)
+			return expression, nil
+		}
+		fmt.Fprintf(&expression.Go, `func(symbol rune) byte { return byte(symbol) }(%v)`, from.Go)
+		return expression, nil
+	}
+
 	return c.CastingError(from, to)
 }
 
@@ -81,7 +147,7 @@ func (Symbol) Native(c *compiler.Compiler) (token compiler.Token) {
 //Zero returns this type's zero expression.
 func (Symbol) Zero(c *compiler.Compiler) (expression compiler.Expression) {
 	expression = c.NewExpression()
-	expression.Type = String{}
+	expression.Type = Symbol{}
 
 	expression.Go.WriteString(`rune(0)`)
 
@@ -0,0 +1,290 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/qlova/viking/compiler"
+	"github.com/qlova/viking/compiler/target"
+)
+
+//Table is an 'i' table, it associates keys with values.
+type Table struct {
+	key     compiler.Type
+	subtype compiler.Type
+}
+
+var _ = compiler.RegisterType(Table{})
+
+var _ = compiler.Collection(Table{})
+
+//Key returns the key type of this table, tables are keyed by strings by default.
+func (table Table) Key() compiler.Type {
+	if table.key == nil {
+		return String{}
+	}
+	return table.key
+}
+
+//Subtype returns the subtype.
+func (table Table) Subtype() compiler.Type {
+	return table.subtype
+}
+
+//Length returns the size/length/count of this type.
+func (table Table) Length(c *compiler.Compiler, this compiler.Expression) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = Integer{}
+	fmt.Fprintf(&expression.Go, `I.NewInteger(int64(len(%v)))`, this.Go)
+	fmt.Fprintf(&expression.JS, `%v.size`, this.JS)
+	return expression
+}
+
+//Name returns the name of this type.
+func (Table) Name() compiler.String {
+	return compiler.String{
+		compiler.English: `table`,
+	}
+}
+
+func (table Table) String(c *compiler.Compiler) string {
+	var name = Table{}.Name()[c.Language]
+	var subtype string
+	if table.subtype != nil {
+		subtype = "." + table.subtype.String(c)
+	}
+	return name + "[" + table.Key().String(c) + "]" + subtype
+}
+
+//Expression does nothing.
+func (Table) Expression(c *compiler.Compiler) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return
+}
+
+//Operation does nothing.
+func (Table) Operation(c *compiler.Compiler, a, b compiler.Expression, symbol string) (ok bool, expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return
+}
+
+//Cast does nothing.
+func (Table) Cast(c *compiler.Compiler, from compiler.Expression, to compiler.Type) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	return c.CastingError(from, to)
+}
+
+//Equals returns true if the other type is equal to this type.
+func (table Table) Equals(other compiler.Type) bool {
+	a, ok := other.(Table)
+
+	if ok && table.key != nil {
+		ok = table.Key().Equals(a.Key())
+	}
+
+	if ok && table.Subtype() != nil {
+		ok = table.Subtype().Equals(a.Subtype())
+	}
+
+	return ok
+}
+
+//Native returns this type's native token.
+func (table Table) Native(c *compiler.Compiler) (token compiler.Token) {
+	var subtype string
+	if table.Subtype() != nil {
+		subtype = table.Subtype().Native(c).String()
+	}
+	if c.Target == target.Go {
+		return compiler.Token(fmt.Sprint("map[", table.native(c), "]", subtype))
+	}
+	if c.Target == target.JS {
+		return compiler.Token("Map")
+	}
+	return
+}
+
+//native returns the native type of the keys of this table.
+func (table Table) native(c *compiler.Compiler) string {
+	if table.Key().Equals(Integer{}) {
+		return "int64"
+	}
+	return table.Key().Native(c).String()
+}
+
+//lookup converts the key expression into the native key of this table.
+func (table Table) lookup(c *compiler.Compiler, key compiler.Expression) (compiler.Expression, error) {
+	if !key.Equals(table.Key()) {
+		return key, c.NewError("table takes 1 ", table.Key().String(c), " key, not ", key.String(c))
+	}
+
+	if key.Equals(Integer{}) {
+		var expression = c.NewExpression()
+		expression.Type = key.Type
+		fmt.Fprintf(&expression.Go, `%v.Int64()`, key.Go)
+		expression.JS.WriteB(key.JS)
+		return expression, nil
+	}
+
+	return key, nil
+}
+
+//Keys returns the keys of the table in ascending order, so that iteration is deterministic.
+func (table Table) Keys(c *compiler.Compiler, this compiler.Expression) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = List{subtype: table.Key()}
+
+	c.Import("sort")
+
+	var native = table.native(c)
+
+	fmt.Fprintf(&expression.Go, `func(table %v) %v { var keys = make([]%v, 0, len(table)); for key := range table { keys = append(keys, key) }; `,
+		table.Native(c), expression.Type.Native(c), native)
+	fmt.Fprintf(&expression.Go, `sort.Slice(keys, func(a, b int) bool { return keys[a] < keys[b] }); `)
+
+	if table.Key().Equals(Integer{}) {
+		fmt.Fprintf(&expression.Go, `var integers = make([]I.Integer, len(keys)); for i, key := range keys { integers[i] = I.NewInteger(key) }; return integers }(%v)`, this.Go)
+	} else {
+		fmt.Fprintf(&expression.Go, `return keys }(%v)`, this.Go)
+	}
+
+	if table.Key().Equals(String{}) {
+		fmt.Fprintf(&expression.JS, `Array.from(%v.keys()).sort()`, this.JS)
+	} else {
+		fmt.Fprintf(&expression.JS, `Array.from(%v.keys()).sort((a, b) => a < b ? -1 : a > b ? 1 : 0)`, this.JS)
+	}
+
+	return
+}
+
+//Contains returns a logical expression that is true when the table contains the key.
+func (table Table) Contains(c *compiler.Compiler, this, key compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	expression.Type = Logical{}
+
+	key, err = table.lookup(c, key)
+	if err != nil {
+		return expression, err
+	}
+
+	fmt.Fprintf(&expression.Go, `func() bool { _, ok := %v[%v]; return ok }()`, this.Go, key.Go)
+	fmt.Fprintf(&expression.JS, `%v.has(%v)`, this.JS, key.JS)
+
+	return expression, nil
+}
+
+//Zero returns this type's zero expression.
+func (table Table) Zero(c *compiler.Compiler) (expression compiler.Expression) {
+	expression = c.NewExpression()
+	expression.Type = table
+
+	fmt.Fprintf(&expression.Go, `%v{}`, table.Native(c))
+	expression.JS.WriteString(`new Map()`)
+
+	return
+}
+
+//Copy returns a copy of the table.
+func (table Table) Copy(c *compiler.Compiler, item compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+	expression.Type = table
+
+	fmt.Fprintf(&expression.Go, `func(table %v) %v { var clone = make(%v, len(table)); for key, value := range table { clone[key] = value }; return clone }(%v)`,
+		table.Native(c), table.Native(c), table.Native(c), item.Go)
+	fmt.Fprintf(&expression.JS, `new Map(%v)`, item.JS)
+
+	return
+}
+
+//Index a value of this type with the specified indicies.
+func (table Table) Index(c *compiler.Compiler, this compiler.Expression, indices ...compiler.Expression) (expression compiler.Expression, err error) {
+	expression = c.NewExpression()
+
+	if len(indices) != 1 {
+		return expression, c.NewError("table takes 1 ", table.Key().String(c), " key")
+	}
+
+	key, err := table.lookup(c, indices[0])
+	if err != nil {
+		return expression, err
+	}
+
+	expression.Type = table.Subtype()
+	fmt.Fprintf(&expression.Go, `%v[%v]`, this.Go, key.Go)
+
+	if table.Subtype() != nil {
+		fmt.Fprintf(&expression.JS, `(%v.has(%v) ? %v.get(%v) : %v)`, this.JS, key.JS, this.JS, key.JS, table.Subtype().Zero(c).JS)
+	}
+
+	return expression, nil
+}
+
+//Modify a value of this type with the specified indicies.
+func (table Table) Modify(c *compiler.Compiler, this compiler.Expression, modification compiler.Expression, indices ...compiler.Expression) error {
+	if len(indices) != 1 {
+		return c.NewError("table takes 1 ", table.Key().String(c), " key")
+	}
+
+	var index = indices[0]
+
+	//t[-] $= key removes the key from the table.
+	if sequencer, ok := index.Type.(Sequencer); ok && sequencer.Minus {
+		key, err := table.lookup(c, modification)
+		if err != nil {
+			return err
+		}
+
+		c.Indent()
+		fmt.Fprintf(&c.Go, `delete(%v, %v)`, this.Go, key.Go)
+		fmt.Fprintf(&c.JS, `%v.delete(%v)`, this.JS, key.JS)
+		return nil
+	}
+
+	if !modification.Equals(table.Subtype()) {
+		return c.NewError("cannot add value of type ", modification.String(c), " to table of type ", table.String(c))
+	}
+
+	key, err := table.lookup(c, index)
+	if err != nil {
+		return err
+	}
+
+	c.Indent()
+	fmt.Fprintf(&c.Go, `%v[%v] = %v`, this.Go, key.Go, modification.Go)
+	fmt.Fprintf(&c.JS, `%v.set(%v, %v)`, this.JS, key.JS, modification.JS)
+	return nil
+}
+
+//Specify this type with the provided args.
+func (table Table) Specify(c *compiler.Compiler, args ...compiler.Expression) (compiler.Type, error) {
+	if len(args) == 0 {
+		return table, nil
+	}
+
+	if len(args) > 1 {
+		return nil, c.NewError("table takes 1 key type argument")
+	}
+
+	meta, ok := args[0].Type.(Metatype)
+	if !ok {
+		return nil, c.NewError("table takes 1 key type argument, not ", args[0].String(c))
+	}
+
+	switch meta.Type.(type) {
+	case String, Integer, Symbol:
+	default:
+		return nil, c.NewError("tables can only be keyed by string, integer or symbol, not ", meta.Type.String(c))
+	}
+
+	table.key = meta.Type
+
+	return table, nil
+}
+
+//With should return this type containing the provided subtype.
+func (table Table) With(c *compiler.Compiler, subtype compiler.Type) compiler.Type {
+	table.subtype = subtype
+	return table
+}
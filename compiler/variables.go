@@ -23,6 +23,30 @@ func (compiler *Context) GetVariable(name Token) Type {
 	return nil
 }
 
+//Parallel returns the index of the scope of the innermost parallel loop, or -1 when there isn't one.
+func (compiler *Context) Parallel() int {
+	for i := len(compiler.Scope) - 1; i >= 0; i-- {
+		if _, ok := compiler.Scope[i].Table["flag_parallel"]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+//Shared returns true if the variable was defined outside of the parallel loop being compiled, it cannot be modified inside of the loop.
+func (compiler *Context) Shared(name Token) bool {
+	var parallel = compiler.Parallel()
+	if parallel < 0 {
+		return false
+	}
+	for i := len(compiler.Scope) - 1; i >= parallel; i-- {
+		if _, ok := compiler.Scope[i].Table[name.String()]; ok {
+			return false
+		}
+	}
+	return Defined(compiler.GetVariable(name))
+}
+
 //DefineVariable defines the variable 'name' with the scanned value.
 func (compiler *Compiler) DefineVariable(name []byte) error {
 	var expression, err = compiler.ScanExpression()
@@ -33,7 +57,8 @@ func (compiler *Compiler) DefineVariable(name []byte) error {
 	if compiler.Flag(Token("thing")) {
 		var thing = compiler.GetVariable(Token("thing")).(Thing)
 		thing.Fields[string(name)] = Field{
-			Type: expression.Type,
+			Type:  expression.Type,
+			Index: len(thing.Fields),
 		}
 	}
 
@@ -43,6 +68,11 @@ func (compiler *Compiler) DefineVariable(name []byte) error {
 	compiler.Go.Write([]byte(" = "))
 	compiler.Go.Write(expression.Go.Bytes())
 
+	compiler.JS.Write([]byte("let "))
+	compiler.JS.Write(name)
+	compiler.JS.Write([]byte(" = "))
+	compiler.JS.Write(expression.JS.Bytes())
+
 	return nil
 }
 
@@ -50,6 +80,10 @@ func (compiler *Compiler) DefineVariable(name []byte) error {
 func (compiler *Compiler) AssignVariable(name []byte) error {
 	var variable = compiler.GetVariable(name)
 
+	if _, ok := compiler.Constants[string(name)]; ok && !Defined(variable) {
+		return compiler.NewError("cannot assign to " + string(name) + ", it is a constant")
+	}
+
 	var expression, err = compiler.ScanExpression()
 	if err != nil {
 		return err
@@ -67,6 +101,10 @@ func (compiler *Compiler) AssignVariable(name []byte) error {
 	compiler.Go.Write([]byte(" = "))
 	compiler.Go.Write(expression.Go.Bytes())
 
+	compiler.JS.Write(name)
+	compiler.JS.Write([]byte(" = "))
+	compiler.JS.Write(expression.JS.Bytes())
+
 	return nil
 }
 
@@ -87,5 +125,9 @@ func (compiler *Compiler) ShortcutAssignVariable(name []byte) error {
 	compiler.Go.Write([]byte(" = "))
 	compiler.Go.Write(expression.Go.Bytes())
 
+	compiler.JS.Write(name)
+	compiler.JS.Write([]byte(" = "))
+	compiler.JS.Write(expression.JS.Bytes())
+
 	return nil
 }
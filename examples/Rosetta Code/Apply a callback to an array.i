@@ -0,0 +1,19 @@
+//output: [1 4 9 16 25]\n[2 4]\n15\n
+square(n)
+	return n * n
+}
+
+even(n)
+	return n % 2 = 0
+}
+
+add(a, b)
+	return a + b
+}
+
+main
+	numbers $= [1, 2, 3, 4, 5]
+	print(map(numbers, square))
+	print(filter(numbers, even))
+	print(reduce(numbers, add))
+}
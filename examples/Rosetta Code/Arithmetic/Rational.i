@@ -0,0 +1,7 @@
+//output: 0.3\n2.5\n1/3\n3\n
+main
+	print(0.1 + 0.2)
+	print(5 / 2.0)
+	print(number(1) / 3)
+	print(integer(3.99))
+}
\ No newline at end of file
@@ -8,5 +8,5 @@ main
 	//Dynamic arrays.
 	d $= list.integer()
 	d[+] $= 2
-	print(d[1])
+	print(d[0])
 }
\ No newline at end of file
@@ -0,0 +1,9 @@
+//output: true\nfalse\n
+main
+	ages $= table[string].integer()
+	ages["alice"] $= 30
+	ages["bob"] $= 25
+	ages[-] $= "bob"
+	print(contains(ages, "alice"))
+	print(contains(ages, "bob"))
+}
\ No newline at end of file
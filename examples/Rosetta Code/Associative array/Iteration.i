@@ -0,0 +1,12 @@
+//output: 3\napple 2\nbanana 5\ncherry 7\n
+main
+	fruit $= table[string].integer()
+	fruit["cherry"] $= 7
+	fruit["apple"] $= 2
+	fruit["banana"] $= 5
+	print(#fruit)
+
+	for name in fruit
+		print(name, fruit[name])
+	}
+}
\ No newline at end of file
@@ -0,0 +1,16 @@
+//output: 8\n14\n40\n2\n15\n240\n41\n
+main
+	a $= 12
+	b $= 10
+	print(a & b)
+	print(a | b)
+	print(b << 2)
+	print(b >> 2)
+
+	nibble $= byte(0xFF) >> 4
+	print(nibble)
+	print(nibble << 4)
+
+	symbol $= 'a' + 200
+	print(byte(symbol))
+}
@@ -0,0 +1,7 @@
+//output: 97\na\nb\n25\n
+main
+	print(integer('a'))
+	print(symbol(97))
+	print('a' + 1)
+	print('z' - 'a')
+}
@@ -0,0 +1,51 @@
+//output: 3628800\n2 3 5 7 11 13 17 19 23 29\n25 is 5 squared\n-4 1 -4 1\n-3 1 -3 1\n
+factorial(n)
+	if n < 2: return 1
+	return n * factorial(n - 1)
+}
+
+primes(n)
+	composite $= array[n + 1].logical()
+	found $= ""
+	for 2 to n
+		if composite[i]: continue
+		if #found > 0: found $= found + " "
+		found $= found + "{i}"
+		multiple $= i * i
+		for multiple < n + 1
+			composite[multiple] $= true
+			multiple $= multiple + i
+		}
+	}
+	return found
+}
+
+squares(n)
+	table $= array[n].integer()
+	for n
+		table[i] $= i * i
+	}
+	return table
+}
+
+//Integers are divided the same way at compile time as they are when the program runs.
+divide(a, b)
+	return "{a / b} {a % b}"
+}
+
+constant ten = factorial(10)
+constant small = primes(30)
+constant square = squares(10)
+constant below = divide(-7, 2)
+constant negative = divide(7, -2)
+
+main
+	print(ten)
+	print(small)
+	print("{square[5]} is 5 squared")
+
+	a $= -7
+	b $= 2
+	print(below, divide(a, b))
+	print(negative, divide(-a, -b))
+}
@@ -0,0 +1,15 @@
+//output: 3 4\n7\n{x: 5, y: 4}\n
+Point { x $= 3; y $= 4 }
+
+Point.sum()
+	return this.x + this.y
+}
+
+main
+	p $= Point()
+	print(p.x, p.y)
+	print(p.sum())
+
+	p.x $= 5
+	print(p)
+}
\ No newline at end of file
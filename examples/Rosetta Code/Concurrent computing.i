@@ -0,0 +1,23 @@
+//output: Enjoy\nRosetta\nCode\n
+say(channel.string(words), channel.logical(turn), string(word))
+	pop(turn)
+	words[+] $= word
+}
+
+main
+	words $= channel.string(3)
+	first $= channel.logical(1)
+	second $= channel.logical(1)
+	third $= channel.logical(1)
+
+	go say(words, third, "Code")
+	go say(words, second, "Rosetta")
+	go say(words, first, "Enjoy")
+
+	first[+] $= true
+	print(pop(words))
+	second[+] $= true
+	print(pop(words))
+	third[+] $= true
+	print(pop(words))
+}
@@ -0,0 +1,14 @@
+//output: 1 odd\n2 even\n-3 odd\n3 small\n100 big\n0.5\n
+parity(integer(n))
+	return if n % 2 = 0: "even" | "odd"
+}
+
+main
+	for n in [1, 2, -3]
+		print(n, parity(n))
+	}
+	x $= -3
+	print(if x < 0: -x | x, if x < 10: "small" | "big")
+	print(if x > 0: 1 | 100, "big")
+	print(if x < 0: 0.5 | 1)
+}
@@ -0,0 +1,18 @@
+//output: opening\nreading 1\nclosing 1\nreading 2\nclosing 2\nchecked 1\nchecked 2\nfound 2\ndone\n
+search(integer(target))
+	for n in [1, 2, 3]
+		defer: print("checked", n)
+		if n = target: return n
+	}
+	return 0
+}
+
+main
+	defer: print("done")
+	print("opening")
+	for n in [1, 2]
+		defer: print("closing", n)
+		print("reading", n)
+	}
+	print("found", search(2))
+}
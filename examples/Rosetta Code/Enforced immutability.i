@@ -0,0 +1,22 @@
+//output: 3.14159\n12\ntic-tac-toe has 9 cells\n25\n
+//The argument of a concept that was defined before the constant is not replaced by it.
+area(integer(side))
+	return side * side
+}
+
+constant pi = 3.14159
+constant side = 3
+constant cells = side * side
+constant game = "tic-tac-toe"
+
+main
+	print(pi)
+
+	board $= array[side * side].integer()
+	board[cells - 1] $= 12
+	print(board[8])
+
+	print(game + " has {cells} cells")
+
+	print(area(5))
+}
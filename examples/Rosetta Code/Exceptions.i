@@ -0,0 +1,15 @@
+//output: 5\n2 division by zero\n
+divide(integer(a), integer(b))
+	if b = 0
+		throw(2, "division by zero")
+		return 0
+	}
+	return a / b
+}
+
+main
+	print(divide(10, 2)); ignore
+	divide(1, 0); for errors
+		print(error.code, error.message)
+	}
+}
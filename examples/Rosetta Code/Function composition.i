@@ -0,0 +1,25 @@
+//output: 7\n8\n16\n
+compose(function[integer].integer(f), function[integer].integer(g))
+	return function(integer(x))
+		return f(g(x))
+	}
+}
+
+double(integer(x))
+	return x * 2
+}
+
+increment(integer(x))
+	return x + 1
+}
+
+square(x)
+	return x * x
+}
+
+main
+	h $= compose(increment, double)
+	print(h(3))
+	print(compose(double, increment)(3))
+	print(compose(square, increment)(3))
+}
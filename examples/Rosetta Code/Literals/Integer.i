@@ -0,0 +1,9 @@
+//output: 727\n727\n727\n727\n1000000\n18446744073709551616\n
+main
+	print(727)
+	print(0b1011010111)
+	print(0o1327)
+	print(0x2d7)
+	print(1_000_000)
+	print(18_446_744_073_709_551_616)
+}
@@ -0,0 +1,8 @@
+//output: 1\n2\n3\n4\n5\n6\n
+main
+	value $= 0
+	for until value % 6 = 0
+		value $= value + 1
+		print(value)
+	}
+}
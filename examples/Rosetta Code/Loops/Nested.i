@@ -0,0 +1,15 @@
+//output: 1 1\n2 1\n2 2\n3 1\n
+main
+	for row in [1, 2, 3]
+		for column in [1, 2, 3]
+			if column > row
+				continue row
+			}
+			if row = 3
+				print(row, column)
+				break row
+			}
+			print(row, column)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+//output: 1024\n512\n256\n128\n64\n32\n16\n8\n4\n2\n1\n
+main
+	n $= 1024
+	for n > 0
+		print(n)
+		n $= n / 2
+	}
+}
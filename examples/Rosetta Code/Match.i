@@ -0,0 +1,20 @@
+//output: 0 zero\n3 few\n8 several\n15 many\n-1 negative\nconsonant\nvowel\n
+main
+	for n in [0, 3, 8, 15, -1]
+		match n
+			0: print(n, "zero")
+			1, 2, 3: print(n, "few")
+			4 to 9
+				print(n, "several")
+			}
+			to -1: print(n, "negative")
+			|: print(n, "many")
+		}
+	}
+	for letter in "hi"
+		match letter
+			'a', 'e', 'i', 'o', 'u': print("vowel")
+			|: print("consonant")
+		}
+	}
+}
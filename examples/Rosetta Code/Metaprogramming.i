@@ -0,0 +1,24 @@
+//output: 25\n3 2\ntotal: 6\n
+square(x) = x * x
+
+swap(a, b) =
+	t $= a
+	a $= b
+	b $= t
+}
+
+main
+	print(square(2 + 3))
+
+	t $= 2
+	u $= 3
+	swap(t, u)
+	print(t, u)
+
+	alias = "total"
+	total $= 0
+	for n in [1, 2, 3]
+		total $= total + n
+	}
+	print("{alias}: {total}")
+}
@@ -0,0 +1,12 @@
+//output: 5\n4\n3\n[[[0 0 0] [0 0 0] [0 0 0] [0 0 0]] [[0 0 0] [0 0 0] [0 1 0] [0 0 0]] [[0 0 0] [0 0 0] [0 0 0] [0 0 0]] [[0 0 0] [0 0 0] [0 0 0] [0 0 0]] [[0 0 0] [0 0 0] [0 0 0] [0 0 0]]]\n1\n
+main
+	a $= array[5, 4, 3].integer()
+
+	print(#a)
+	print(#a[0])
+	print(#a[0, 0])
+
+	a[1, 2, 1] $= 1
+	print(a)
+	print(a[1, 2, 1])
+}
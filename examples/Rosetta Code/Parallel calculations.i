@@ -0,0 +1,18 @@
+//output: 3 47\n
+main
+	numbers $= [12757923, 12878611, 12878893, 12757923, 15808973, 15780709, 197622519]
+
+	smallest $= numbers[0]
+	largest $= 0
+	for n in numbers parallel smallest min, largest max
+		factor $= 2
+		for factor * factor < n + 1
+			if n % factor = 0: break
+			factor $= factor + 1
+		}
+		if factor * factor > n: factor $= n
+		if factor < smallest: smallest $= factor
+		if factor > largest: largest $= factor
+	}
+	print(smallest, largest)
+}
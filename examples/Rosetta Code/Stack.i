@@ -0,0 +1,14 @@
+//output: 3\n3\n2\n1\n0\n
+main
+	stack $= list.integer()
+
+	stack[+] $= 1
+	stack[+] $= 2
+	stack[+] $= 3
+	print(#stack)
+
+	print(pop(stack))
+	print(pop(stack))
+	print(pop(stack))
+	print(#stack)
+}
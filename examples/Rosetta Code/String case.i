@@ -0,0 +1,6 @@
+//output: ALPHABETA\nalphabeta\n
+main
+	s $= "alphaBETA"
+	print(upper(s))
+	print(lower(s))
+}
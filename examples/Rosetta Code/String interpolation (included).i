@@ -0,0 +1,9 @@
+//output: Mary had a little lamb.\n"2 + 3" is 5\n\tindented {literal} ☺\n
+main
+	extra $= "little"
+	print("Mary had a {extra} lamb.")
+	a $= 2
+	b $= 3
+	print("\"{a} + {b}\" is {a + b}")
+	print("\tindented \{literal\} \u{263A}")
+}
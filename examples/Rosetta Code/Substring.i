@@ -0,0 +1,9 @@
+//output: cdef\ncdefgh\nabcdefg\n
+main
+	s $= "abcdefgh"
+	n $= 2
+	m $= 4
+	print(s[n to n + m - 1])
+	print(s[n to])
+	print(s[to -2])
+}
@@ -0,0 +1,5 @@
+//output: Hello.How.Are.You.Today\n
+main
+	tokens $= split("Hello,How,Are,You,Today", ",")
+	print(join(tokens, "."))
+}